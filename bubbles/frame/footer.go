@@ -0,0 +1,63 @@
+package frame
+
+import (
+	"io"
+	"sync"
+
+	"github.com/anchore/bubbly/bubbles/internal/linebuffer"
+)
+
+const (
+	// DefaultFooterLines is the number of log lines retained for the footer unless changed with SetFooterLimits.
+	DefaultFooterLines = 1000
+	// DefaultFooterBytes is the number of bytes of log output retained for the footer unless changed with
+	// SetFooterLimits.
+	DefaultFooterBytes = 1 << 20
+)
+
+// footer holds the most recent log output written to the frame. It is safe to write to from other goroutines while
+// the frame is being rendered. Reading from the footer consumes what has been retained.
+type footer struct {
+	*linebuffer.Buffer
+	lock *sync.Mutex
+	tee  io.Writer
+}
+
+func newFooter() *footer {
+	return &footer{
+		Buffer: linebuffer.New(DefaultFooterLines, DefaultFooterBytes),
+		lock:   &sync.Mutex{},
+	}
+}
+
+// Write retains the output for the footer and writes it to the tee writer (if any), returning any error from the
+// tee writer.
+func (l *footer) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	n, err := l.Buffer.Write(p)
+	if err != nil || l.tee == nil {
+		return n, err
+	}
+	return l.tee.Write(p)
+}
+
+func (l *footer) setTee(w io.Writer) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.tee = w
+}
+
+// SetFooterLimits caps the amount of log output retained for the footer to the most recent maxLines lines and maxBytes
+// bytes (zero or less is unbounded). The oldest lines are discarded first.
+func (f *Frame) SetFooterLimits(maxLines, maxBytes int) {
+	f.footer.SetLimits(maxLines, maxBytes)
+}
+
+// TeeFooter writes all log output written to the footer to the given writer as well (e.g. a log file), regardless of
+// the footer limits. Pass nil to stop.
+func (f *Frame) TeeFooter(w io.Writer) {
+	f.footer.setTee(w)
+}
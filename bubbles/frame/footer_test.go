@@ -0,0 +1,96 @@
+package frame
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrame_Footer_Limits(t *testing.T) {
+	f := New()
+	f.TruncateFooter(false)
+	f.SetFooterLimits(2, 0)
+	f.AppendModel(mockModel{view: "visible"})
+
+	_, _ = f.Footer().Write([]byte("log 1\nlog 2\nlog 3\n"))
+
+	assert.Equal(t, "visible\nlog 2\nlog 3", f.View())
+}
+
+func TestFrame_Footer_Tee(t *testing.T) {
+	f := New()
+	f.SetFooterLimits(1, 0)
+
+	tee := &bytes.Buffer{}
+	f.TeeFooter(tee)
+
+	_, _ = f.Footer().Write([]byte("log 1\nlog 2\n"))
+	_, _ = f.Footer().Write([]byte("log 3\n"))
+
+	// the full log is kept, regardless of the footer limits
+	assert.Equal(t, "log 1\nlog 2\nlog 3\n", tee.String())
+
+	f.TeeFooter(nil)
+	_, _ = f.Footer().Write([]byte("log 4\n"))
+	assert.Equal(t, "log 1\nlog 2\nlog 3\n", tee.String())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestFrame_Footer_TeeError(t *testing.T) {
+	f := New()
+	f.TeeFooter(failingWriter{})
+
+	_, err := f.Footer().Write([]byte("log 1\n"))
+	require.Error(t, err)
+
+	// the output is still shown in the footer
+	assert.Equal(t, "log 1", f.View())
+}
+
+func TestFrame_Footer_Read(t *testing.T) {
+	f := New()
+	_, _ = f.Footer().Write([]byte("log 1\nlog 2"))
+
+	got, err := io.ReadAll(f.Footer())
+	require.NoError(t, err)
+	assert.Equal(t, "log 1\nlog 2", string(got))
+
+	// reading consumes the footer
+	assert.Empty(t, f.View())
+}
+
+func TestFrame_Footer_ConcurrentWrites(t *testing.T) {
+	f := New()
+	f.AppendModel(mockModel{view: "visible"})
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, _ = fmt.Fprintf(f.Footer(), "log %d\n", j)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = f.View()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, f.footer.Lines(), 400)
+}
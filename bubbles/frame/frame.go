@@ -1,10 +1,7 @@
 package frame
 
 import (
-	"bytes"
-	"fmt"
 	"io"
-	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -26,28 +23,47 @@ type ImprintableElement interface {
 	ShouldImprint() bool
 }
 
+// ReleasableElement allows UI elements to free resources they hold (e.g. a registration with a shared timer) when the
+// frame drops them: when they are removed or replaced, or pruned at the end of their lifecycle.
+type ReleasableElement interface {
+	Release()
+}
+
 type Frame struct {
-	footer         *bytes.Buffer
+	footer         *footer
 	models         []annotatedModel
 	windowSize     tea.WindowSizeMsg
 	showFooter     bool
 	truncateFooter bool
+	viewport       viewport
+	budgets        map[Region]Budget
+	transcript     transcript
+	initialized    bool
+	pendingInit    []tea.Cmd
 }
 
 type annotatedModel struct {
 	model   tea.Model
 	expired bool
 	hidden  bool
+	region  Region
+	key     string
 }
 
 func New() *Frame {
 	return &Frame{
-		footer:         &bytes.Buffer{},
+		footer:         newFooter(),
 		showFooter:     true,
 		truncateFooter: true,
+		viewport: viewport{
+			keys: DefaultViewportKeyMap(),
+		},
+		budgets: defaultBudgets(),
 	}
 }
 
+// Footer is where log output shown beneath the frame elements is written (see SetFooterLimits and TeeFooter). It is
+// safe to write to from any goroutine.
 func (f Frame) Footer() io.ReadWriter {
 	return f.footer
 }
@@ -60,47 +76,81 @@ func (f *Frame) TruncateFooter(set bool) {
 	f.truncateFooter = set
 }
 
+// AppendModel adds a UI element to the body region.
 func (f *Frame) AppendModel(uiElement tea.Model) {
-	f.models = append(f.models, annotatedModel{model: uiElement})
+	f.AppendModelTo(RegionBody, uiElement)
+}
+
+// Init initializes all UI elements added so far, batching their commands. UI elements added afterward are initialized
+// on the next update.
+func (f *Frame) Init() tea.Cmd {
+	f.initialized = true
+	f.pendingInit = nil
+
+	var cmds []tea.Cmd
+	for _, el := range f.models {
+		cmds = append(cmds, el.model.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+// initModel queues the Init command of a UI element added after the frame was initialized, to be run on the next
+// update (UI elements added before then are initialized by Init).
+func (f *Frame) initModel(uiElement tea.Model) {
+	if !f.initialized {
+		return
+	}
+	if cmd := uiElement.Init(); cmd != nil {
+		f.pendingInit = append(f.pendingInit, cmd)
+	}
 }
 
-func (f Frame) Init() tea.Cmd {
-	return nil
+func (f *Frame) flushInit() tea.Cmd {
+	cmd := tea.Batch(f.pendingInit...)
+	f.pendingInit = nil
+	return cmd
 }
 
 func (f *Frame) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
 		f.windowSize = msg
+	case tea.KeyMsg:
+		if f.handleViewportKey(msg) {
+			return f, f.flushInit()
+		}
 	}
 
-	var cmds []tea.Cmd
+	// run the Init commands of any UI elements added since the last update
+	cmds := []tea.Cmd{f.flushInit()}
 
 	// 1. prune any models that are no longer alive
 	// 2. hide/show any models based on the latest state
 	// 3. trail any models that are expired, pruning them on the next update
 	for i := 0; i < len(f.models); i++ {
 		if p, ok := f.models[i].model.(TerminalElement); ok && !p.IsAlive() {
+			release(f.models[i].model)
 			f.models = append(f.models[:i], f.models[i+1:]...)
 			i--
 			continue
 		}
 
 		if f.models[i].expired {
+			release(f.models[i].model)
 			f.models = append(f.models[:i], f.models[i+1:]...)
 			i--
 			continue
 		}
 
-		if p, ok := f.models[i].model.(VisibleElement); ok && p.IsHidden() {
-			f.models[i].hidden = true
-		} else {
-			f.models[i].hidden = false
-		}
+		f.models[i].hidden = isHidden(f.models[i].model)
 
 		if p, ok := f.models[i].model.(ImprintableElement); ok && p.ShouldImprint() {
 			f.models[i].expired = true
 
-			cmd := tea.Printf("%s", f.models[i].model.View())
+			view := f.models[i].model.View()
+			f.transcript.write(view)
+
+			cmd := tea.Printf("%s", view)
 			cmds = append(cmds, cmd)
 		}
 	}
@@ -117,38 +167,5 @@ func (f *Frame) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (f Frame) View() string {
-	// all UI elements
-	var strs []string
-	for _, p := range f.models {
-		if p.hidden {
-			continue
-		}
-		rendered := p.model.View()
-		if len(rendered) > 0 {
-			strs = append(strs, rendered)
-		}
-	}
-
-	str := strings.Join(strs, "\n")
-
-	// log events
-	if f.showFooter {
-		contents := f.footer.String()
-		if f.truncateFooter {
-			logLines := strings.Split(contents, "\n")
-			logMax := f.windowSize.Height - strings.Count(str, "\n")
-			trimLog := len(logLines) - logMax
-			if trimLog > 0 && len(logLines) >= trimLog {
-				logLines = logLines[trimLog:]
-			}
-			for _, line := range logLines {
-				if len(line) > 0 {
-					str += fmt.Sprintf("%s\n", line)
-				}
-			}
-		} else {
-			str += contents
-		}
-	}
-	return str
+	return f.render()
 }
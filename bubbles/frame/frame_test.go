@@ -6,6 +6,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/bubbly/bubbles/internal/testutil"
 )
 
 type mockModel struct {
@@ -222,3 +224,239 @@ func TestFrame_View_NoFooter(t *testing.T) {
 	assert.NotContains(t, viewOutput, "log line 1")
 	assert.NotContains(t, viewOutput, "log line 2")
 }
+
+type mockCompletableElement struct {
+	mockModel
+	completed bool
+	failed    bool
+	succeeded bool
+}
+
+func (m mockCompletableElement) Update(_ tea.Msg) (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func (m mockCompletableElement) IsCompleted() bool {
+	return m.completed
+}
+
+func (m mockCompletableElement) IsFailed() bool {
+	return m.failed
+}
+
+func (m mockCompletableElement) IsSucceeded() bool {
+	return m.succeeded
+}
+
+func completed(view string) tea.Model {
+	return mockCompletableElement{mockModel: mockModel{view: view}, completed: true, succeeded: true}
+}
+
+func running(view string) tea.Model {
+	return mockCompletableElement{mockModel: mockModel{view: view}}
+}
+
+func failed(view string) tea.Model {
+	return mockCompletableElement{mockModel: mockModel{view: view}, completed: true, failed: true}
+}
+
+// unsuccessful is an element that finished without failing, but did not succeed either (e.g. canceled or with
+// warnings).
+func unsuccessful(view string) tea.Model {
+	return mockCompletableElement{mockModel: mockModel{view: view}, completed: true}
+}
+
+func viewportSubject(height int, models ...tea.Model) *Frame {
+	f := New()
+	f.ShowFooter(false)
+	f.Viewport(true)
+	for _, m := range models {
+		f.AppendModel(m)
+	}
+	f.Update(tea.WindowSizeMsg{Width: 80, Height: height})
+	return f
+}
+
+func TestFrame_View_Viewport(t *testing.T) {
+	tests := []struct {
+		name   string
+		height int
+		models []tea.Model
+		want   string
+	}{
+		{
+			name:   "everything fits",
+			height: 3,
+			models: []tea.Model{completed("c1"), running("r1"), failed("f1")},
+			want:   "c1\nr1\nf1",
+		},
+		{
+			name:   "oldest completed elements are collapsed first",
+			height: 5,
+			models: []tea.Model{
+				completed("c1"), running("r1"), completed("c2"), completed("c3"), failed("f1"), completed("c4"), completed("c5"),
+			},
+			want: "… and 3 more completed\nr1\nf1\nc4\nc5",
+		},
+		{
+			name:   "elements that did not succeed are never collapsed",
+			height: 4,
+			models: []tea.Model{completed("c1"), unsuccessful("w1"), completed("c2"), completed("c3"), running("r1")},
+			want:   "… and 2 more completed\nw1\nc3\nr1",
+		},
+		{
+			name:   "multi-line elements",
+			height: 4,
+			models: []tea.Model{completed("c1\n  detail"), running("r1\n  log"), completed("c2")},
+			want:   "… and 1 more completed\nr1\n  log\nc2",
+		},
+		{
+			name:   "running elements are only dropped when collapsing is not enough",
+			height: 3,
+			models: []tea.Model{running("r1"), completed("c1"), running("r2"), running("r3")},
+			want:   "… and 2 more\nr2\nr3",
+		},
+		{
+			name:   "elements without a lifecycle are never collapsed",
+			height: 3,
+			models: []tea.Model{mockModel{view: "m1"}, completed("c1"), completed("c2"), mockModel{view: "m2"}},
+			want:   "… and 2 more completed\nm1\nm2",
+		},
+		{
+			name:   "the most recent element is kept even when it is too tall",
+			height: 3,
+			models: []tea.Model{running("r1"), running("r2\na\nb\nc")},
+			want:   "… and 1 more\nb\nc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := viewportSubject(tt.height, tt.models...)
+			assert.Equal(t, tt.want, f.View())
+		})
+	}
+}
+
+func TestFrame_View_ViewportDisabled(t *testing.T) {
+	f := viewportSubject(2, completed("c1"), completed("c2"), running("r1"))
+	f.Viewport(false)
+
+	assert.Equal(t, "c1\nc2\nr1", f.View())
+}
+
+func TestFrame_Update_ViewportScrolling(t *testing.T) {
+	key := func(k string) tea.KeyMsg {
+		switch k {
+		case "up":
+			return tea.KeyMsg{Type: tea.KeyUp}
+		case "down":
+			return tea.KeyMsg{Type: tea.KeyDown}
+		case "pgup":
+			return tea.KeyMsg{Type: tea.KeyPgUp}
+		case "home":
+			return tea.KeyMsg{Type: tea.KeyHome}
+		case "end":
+			return tea.KeyMsg{Type: tea.KeyEnd}
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	}
+
+	f := viewportSubject(3, completed("c1"), completed("c2"), completed("c3"), completed("c4"), running("r1"), running("r2"))
+	require.Equal(t, "… and 4 more completed\nr1\nr2", f.View())
+
+	steps := []struct {
+		key  string
+		want string
+	}{
+		{key: "up", want: "c3\nc4\nr1"},
+		{key: "up", want: "c2\nc3\nc4"},
+		{key: "pgup", want: "c1\nc2\nc3"},
+		{key: "down", want: "c2\nc3\nc4"},
+		{key: "end", want: "… and 4 more completed\nr1\nr2"},
+		{key: "home", want: "c1\nc2\nc3"},
+		{key: "down", want: "c2\nc3\nc4"},
+		{key: "down", want: "c3\nc4\nr1"},
+		// scrolling to the end returns to following
+		{key: "down", want: "… and 4 more completed\nr1\nr2"},
+	}
+	for _, s := range steps {
+		_, cmd := f.Update(key(s.key))
+		assert.Nil(t, cmd)
+		assert.Equal(t, s.want, f.View(), "after %q", s.key)
+	}
+}
+
+func TestFrame_Update_ViewportKeysForwardedWhenDisabled(t *testing.T) {
+	f := New()
+	f.AppendModel(mockModel{})
+
+	f.Update(tea.KeyMsg{Type: tea.KeyUp})
+
+	assert.True(t, f.models[0].model.(mockModel).updateCalled)
+}
+
+func TestFrame_Update_ViewportKeysForwardedWithoutOverflow(t *testing.T) {
+	f := viewportSubject(3)
+	f.AppendModel(mockModel{view: "prompt"})
+
+	f.Update(tea.KeyMsg{Type: tea.KeyUp})
+
+	assert.True(t, f.models[0].model.(mockModel).updateCalled)
+}
+
+func TestFrame_Update_LetterKeysForwardedWhileOverflowing(t *testing.T) {
+	f := viewportSubject(2, running("r1"), running("r2"), running("r3"))
+	f.AppendModel(mockModel{view: "prompt"})
+
+	for _, k := range []string{"k", "j", "g", "G"} {
+		f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		assert.True(t, f.models[3].model.(mockModel).updateCalled, k)
+		assert.False(t, f.viewport.scrolling, k)
+	}
+}
+
+type initMsg string
+
+type mockInitElement struct {
+	mockModel
+}
+
+func (m mockInitElement) Init() tea.Cmd {
+	return func() tea.Msg {
+		return initMsg(m.view)
+	}
+}
+
+func (m mockInitElement) Update(_ tea.Msg) (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func TestFrame_Init(t *testing.T) {
+	f := New()
+	f.AppendModel(mockInitElement{mockModel{view: "a"}})
+	f.AppendModel(mockModel{view: "no init"})
+	require.NoError(t, f.AppendKeyedTo(RegionHeader, "b", mockInitElement{mockModel{view: "b"}}))
+
+	assert.ElementsMatch(t, []tea.Msg{initMsg("a"), initMsg("b")}, testutil.Messages(f.Init()))
+
+	// elements added after the frame was initialized are initialized on the next update (only once)
+	f.AppendModel(mockInitElement{mockModel{view: "c"}})
+	require.NoError(t, f.InsertAt(0, "d", mockInitElement{mockModel{view: "d"}}))
+	require.NoError(t, f.Replace("b", mockInitElement{mockModel{view: "e"}}))
+
+	_, cmd := f.Update(nil)
+	assert.ElementsMatch(t, []tea.Msg{initMsg("c"), initMsg("d"), initMsg("e")}, testutil.Messages(cmd))
+
+	_, cmd = f.Update(nil)
+	assert.Empty(t, testutil.Messages(cmd))
+}
+
+func TestFrame_Init_FlushedOnViewportKey(t *testing.T) {
+	f := viewportSubject(2, running("a"), running("b"), running("c"))
+	f.Init()
+
+	f.AppendModel(mockInitElement{mockModel{view: "d"}})
+	_, cmd := f.Update(tea.KeyMsg{Type: tea.KeyUp})
+
+	assert.Equal(t, []tea.Msg{initMsg("d")}, testutil.Messages(cmd))
+}
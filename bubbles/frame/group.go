@@ -0,0 +1,237 @@
+package frame
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/anchore/bubbly/bubbles/glyphs"
+)
+
+var (
+	_ tea.Model          = (*Group)(nil)
+	_ VisibleElement     = (*Group)(nil)
+	_ TerminalElement    = (*Group)(nil)
+	_ ImprintableElement = (*Group)(nil)
+	_ CompletableElement = (*Group)(nil)
+	_ ReleasableElement  = (*Group)(nil)
+)
+
+// Group renders related UI elements (e.g. all catalogers) beneath a header line. Once all members have succeeded the
+// group collapses into the header line alone, and whenever a member fails the group is expanded so the failure is
+// visible. The state of the group is aggregated from its members (see CompletableElement), where members that do not
+// report completion are considered to have succeeded.
+//
+// The lifecycle of members is managed by the group rather than the frame: members that are no longer alive are
+// removed from the group, and members that want to be imprinted are kept until the whole group is imprinted.
+type Group struct {
+	Title  string
+	Indent string
+
+	TitleStyle   lipgloss.Style
+	HintStyle    lipgloss.Style
+	SuccessStyle lipgloss.Style
+	FailedStyle  lipgloss.Style
+
+	members      []tea.Model
+	removed      int // members that were pruned, which are considered complete
+	autoCollapse bool
+	glyphs       glyphs.Set
+	initialized  bool
+	pendingInit  []tea.Cmd
+}
+
+func NewGroup(title string, members ...tea.Model) *Group {
+	return &Group{
+		Title:        title,
+		Indent:       "  ",
+		TitleStyle:   lipgloss.NewStyle().Bold(true),
+		HintStyle:    lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")),
+		SuccessStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("10")), // 10 = high intensity green (ANSI 16 bit color code)
+		FailedStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("9")),  // 9 = high intensity red (ANSI 16 bit color code)
+		members:      members,
+		autoCollapse: true,
+		glyphs:       glyphs.Detect(),
+	}
+}
+
+// Append adds a UI element to the end of the group. UI elements added after the group was initialized are initialized
+// on the next update.
+func (g *Group) Append(uiElement tea.Model) {
+	g.members = append(g.members, uiElement)
+	if g.initialized {
+		g.pendingInit = append(g.pendingInit, uiElement.Init())
+	}
+}
+
+func (g Group) Members() []tea.Model {
+	return append([]tea.Model(nil), g.members...)
+}
+
+// AutoCollapse controls whether the group collapses into its header line once all members have succeeded.
+func (g *Group) AutoCollapse(set bool) {
+	g.autoCollapse = set
+}
+
+func (g *Group) SetGlyphs(set glyphs.Set) {
+	g.glyphs = set
+}
+
+func (g *Group) Init() tea.Cmd {
+	g.initialized = true
+	g.pendingInit = nil
+
+	var cmds []tea.Cmd
+	for _, m := range g.members {
+		cmds = append(cmds, m.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (g *Group) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	cmds := g.pendingInit
+	g.pendingInit = nil
+
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		// members are rendered indented, so they have less room to work with
+		size.Width -= lipgloss.Width(g.Indent)
+		msg = size
+	}
+
+	members := make([]tea.Model, 0, len(g.members))
+	for _, m := range g.members {
+		if p, ok := m.(TerminalElement); ok && !p.IsAlive() {
+			release(m)
+			g.removed++
+			continue
+		}
+		m, cmd := m.Update(msg)
+		cmds = append(cmds, cmd)
+		members = append(members, m)
+	}
+	g.members = members
+	return g, tea.Batch(cmds...)
+}
+
+func (g Group) View() string {
+	done, failed, total := g.counts()
+
+	if g.Collapsed() {
+		return fmt.Sprintf("%s %s %s",
+			g.SuccessStyle.Render(g.glyphs.Success),
+			g.TitleStyle.Render(g.Title),
+			g.HintStyle.Render(fmt.Sprintf("%d completed", total)),
+		)
+	}
+
+	hint := fmt.Sprintf("%d/%d completed", done, total)
+	header := g.TitleStyle.Render(g.Title)
+	if failed > 0 {
+		header = g.FailedStyle.Render(g.glyphs.Failed) + " " + header
+		hint += fmt.Sprintf(", %d failed", failed)
+	}
+
+	lines := []string{header + " " + g.HintStyle.Render(hint)}
+	for _, m := range g.members {
+		if isHidden(m) {
+			continue
+		}
+		rendered := m.View()
+		if len(rendered) == 0 {
+			continue
+		}
+		for _, line := range strings.Split(rendered, "\n") {
+			lines = append(lines, g.Indent+line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Release releases all members (see ReleasableElement).
+func (g Group) Release() {
+	for _, m := range g.members {
+		release(m)
+	}
+}
+
+// Collapsed indicates that only the header line of the group is shown, which is once all members have succeeded
+// (members that were canceled or finished with warnings keep the group expanded).
+func (g Group) Collapsed() bool {
+	return g.autoCollapse && g.IsCompleted() && g.IsSucceeded()
+}
+
+// IsHidden indicates that there is nothing to show for the group (it has no members).
+func (g Group) IsHidden() bool {
+	return len(g.members) == 0 && g.removed == 0
+}
+
+// IsAlive indicates that the group should be kept in the frame, which is until all of its members have been removed.
+func (g Group) IsAlive() bool {
+	return len(g.members) > 0 || g.removed == 0
+}
+
+// ShouldImprint indicates that the group has completed and all members that can be imprinted want to be (at least
+// one must), in which case the whole group is imprinted at once.
+func (g Group) ShouldImprint() bool {
+	if !g.IsCompleted() {
+		return false
+	}
+	var imprintable bool
+	for _, m := range g.members {
+		p, ok := m.(ImprintableElement)
+		if !ok {
+			continue
+		}
+		if !p.ShouldImprint() {
+			return false
+		}
+		imprintable = true
+	}
+	return imprintable
+}
+
+// IsCompleted indicates that all members have completed.
+func (g Group) IsCompleted() bool {
+	for _, m := range g.members {
+		if c, ok := m.(CompletableElement); ok && !c.IsCompleted() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSucceeded indicates that all members have succeeded.
+func (g Group) IsSucceeded() bool {
+	for _, m := range g.members {
+		if c, ok := m.(CompletableElement); ok && !c.IsSucceeded() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFailed indicates that at least one member has failed.
+func (g Group) IsFailed() bool {
+	_, failed, _ := g.counts()
+	return failed > 0
+}
+
+func (g Group) counts() (done, failed, total int) {
+	done, total = g.removed, g.removed+len(g.members)
+	for _, m := range g.members {
+		c, ok := m.(CompletableElement)
+		if !ok {
+			done++
+			continue
+		}
+		if c.IsCompleted() {
+			done++
+		}
+		if c.IsFailed() {
+			failed++
+		}
+	}
+	return done, failed, total
+}
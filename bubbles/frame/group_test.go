@@ -0,0 +1,245 @@
+package frame
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/bubbly/bubbles/internal/testutil"
+
+	"github.com/anchore/bubbly/bubbles/glyphs"
+)
+
+func groupSubject(members ...tea.Model) *Group {
+	g := NewGroup("catalogers", members...)
+	g.SetGlyphs(glyphs.Unicode)
+	g.TitleStyle = lipgloss.NewStyle()
+	g.HintStyle = lipgloss.NewStyle()
+	g.SuccessStyle = lipgloss.NewStyle()
+	g.FailedStyle = lipgloss.NewStyle()
+	return g
+}
+
+func TestGroup_View(t *testing.T) {
+	tests := []struct {
+		name          string
+		members       []tea.Model
+		noCollapse    bool
+		want          string
+		wantCompleted bool
+		wantFailed    bool
+	}{
+		{
+			name:    "running",
+			members: []tea.Model{completed("apk"), running("dpkg"), mockModel{view: "static"}},
+			want:    "catalogers 2/3 completed\n  apk\n  dpkg\n  static",
+		},
+		{
+			name:          "collapses once all members succeed",
+			members:       []tea.Model{completed("apk"), completed("dpkg")},
+			want:          "✔ catalogers 2 completed",
+			wantCompleted: true,
+		},
+		{
+			name:          "auto collapse disabled",
+			members:       []tea.Model{completed("apk"), completed("dpkg")},
+			noCollapse:    true,
+			want:          "catalogers 2/2 completed\n  apk\n  dpkg",
+			wantCompleted: true,
+		},
+		{
+			name:          "members that did not succeed keep the group expanded",
+			members:       []tea.Model{completed("apk"), unsuccessful("dpkg"), unsuccessful("rpm")},
+			want:          "catalogers 3/3 completed\n  apk\n  dpkg\n  rpm",
+			wantCompleted: true,
+		},
+		{
+			name:       "expanded on failure",
+			members:    []tea.Model{failed("apk"), running("dpkg")},
+			want:       "✘ catalogers 1/2 completed, 1 failed\n  apk\n  dpkg",
+			wantFailed: true,
+		},
+		{
+			name:          "stays expanded on failure once completed",
+			members:       []tea.Model{failed("apk"), completed("dpkg")},
+			want:          "✘ catalogers 2/2 completed, 1 failed\n  apk\n  dpkg",
+			wantCompleted: true,
+			wantFailed:    true,
+		},
+		{
+			name:    "multi-line members and hidden members",
+			members: []tea.Model{running("apk\n  detail"), mockVisibleElement{mockModel: mockModel{view: "hidden"}, isHidden: true}},
+			want:    "catalogers 1/2 completed\n  apk\n    detail",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := groupSubject(tt.members...)
+			g.AutoCollapse(!tt.noCollapse)
+
+			assert.Equal(t, tt.want, g.View())
+			assert.Equal(t, tt.wantCompleted, g.IsCompleted())
+			assert.Equal(t, tt.wantFailed, g.IsFailed())
+		})
+	}
+}
+
+func TestGroup_Update_AutoExpandsOnFailure(t *testing.T) {
+	g := groupSubject(completed("apk"), running("dpkg"))
+	assert.False(t, g.Collapsed())
+
+	g.members[1] = completed("dpkg")
+	assert.True(t, g.Collapsed())
+
+	g.Append(failed("rpm"))
+	assert.False(t, g.Collapsed())
+	assert.Equal(t, "✘ catalogers 3/3 completed, 1 failed\n  apk\n  dpkg\n  rpm", g.View())
+}
+
+func TestGroup_Update_PrunesMembers(t *testing.T) {
+	g := groupSubject(mockTerminalElement{mockModel: mockModel{view: "removed"}, isAlive: false}, running("dpkg"))
+
+	_, _ = g.Update(nil)
+
+	require.Len(t, g.Members(), 1)
+	assert.Equal(t, "catalogers 1/2 completed\n  dpkg", g.View())
+	assert.True(t, g.IsAlive())
+
+	g.members[0] = mockTerminalElement{mockModel: mockModel{view: "removed"}, isAlive: false}
+	_, _ = g.Update(nil)
+
+	// once all members have been removed so is the group
+	assert.False(t, g.IsAlive())
+}
+
+func TestGroup_ReleasesMembers(t *testing.T) {
+	var released int
+	g := groupSubject(
+		mockReleasableElement{mockTerminalElement: mockTerminalElement{mockModel: mockModel{view: "removed"}, isAlive: false}, released: &released},
+		mockReleasableElement{mockTerminalElement: mockTerminalElement{mockModel: mockModel{view: "dpkg"}, isAlive: true}, released: &released},
+	)
+
+	// pruned members are released...
+	_, _ = g.Update(nil)
+	assert.Equal(t, 1, released)
+
+	// ...as are the remaining members once the group is released
+	g.Release()
+	assert.Equal(t, 2, released)
+}
+
+// mockSizedElement fills the width of the window it was given.
+type mockSizedElement struct {
+	width int
+}
+
+func (m mockSizedElement) Init() tea.Cmd {
+	return nil
+}
+
+func (m mockSizedElement) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = size.Width
+	}
+	return m, nil
+}
+
+func (m mockSizedElement) View() string {
+	return strings.Repeat("x", m.width)
+}
+
+func TestGroup_Update_MembersFitTheWindow(t *testing.T) {
+	g := groupSubject(mockSizedElement{}, running("dpkg"))
+
+	_, _ = g.Update(tea.WindowSizeMsg{Width: 60, Height: 20})
+
+	for _, line := range strings.Split(g.View(), "\n") {
+		assert.LessOrEqual(t, lipgloss.Width(line), 60, line)
+	}
+	assert.Contains(t, g.View(), "  "+strings.Repeat("x", 58))
+}
+
+type mockTaskElement struct {
+	mockCompletableElement
+	imprint bool
+}
+
+func (m mockTaskElement) Update(_ tea.Msg) (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func (m mockTaskElement) ShouldImprint() bool {
+	return m.imprint
+}
+
+func TestGroup_ShouldImprint(t *testing.T) {
+	task := func(view string, done, imprint bool) tea.Model {
+		return mockTaskElement{
+			mockCompletableElement: mockCompletableElement{mockModel: mockModel{view: view}, completed: done},
+			imprint:                imprint,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		members []tea.Model
+		want    bool
+	}{
+		{
+			name:    "all members imprint",
+			members: []tea.Model{task("apk", true, true), task("dpkg", true, true), mockModel{view: "static"}},
+			want:    true,
+		},
+		{
+			name:    "a member is still running",
+			members: []tea.Model{task("apk", true, true), task("dpkg", false, false)},
+		},
+		{
+			name:    "a member stays live",
+			members: []tea.Model{task("apk", true, true), task("dpkg", true, false)},
+		},
+		{
+			name:    "no imprintable members",
+			members: []tea.Model{completed("apk")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, groupSubject(tt.members...).ShouldImprint())
+		})
+	}
+}
+
+func TestFrame_Group(t *testing.T) {
+	f := New()
+	f.Viewport(true)
+	g := groupSubject(completed("apk"), running("dpkg"))
+	f.AppendModel(completed("before"))
+	f.AppendModel(g)
+	f.AppendModel(mockModel{view: "after"})
+
+	f.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+	assert.Equal(t, "before\ncatalogers 1/2 completed\n  apk\n  dpkg\nafter", f.View())
+
+	g.members[1] = completed("dpkg")
+	f.Update(nil)
+	assert.Equal(t, "before\n✔ catalogers 2 completed\nafter", f.View())
+
+	// a collapsed group is collapsed further by the viewport when space is short
+	f.Update(tea.WindowSizeMsg{Width: 80, Height: 2})
+	assert.Equal(t, "… and 2 more completed\nafter", f.View())
+}
+
+func TestGroup_Init(t *testing.T) {
+	g := groupSubject(mockInitElement{mockModel{view: "a"}}, running("b"))
+
+	assert.Equal(t, []tea.Msg{initMsg("a")}, testutil.Messages(g.Init()))
+
+	g.Append(mockInitElement{mockModel{view: "c"}})
+	_, cmd := g.Update(nil)
+	assert.Equal(t, []tea.Msg{initMsg("c")}, testutil.Messages(cmd))
+}
@@ -0,0 +1,156 @@
+package frame
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	ErrEmptyKey     = errors.New("key cannot be empty")
+	ErrDuplicateKey = errors.New("key is already in use")
+	ErrKeyNotFound  = errors.New("key not found")
+)
+
+// Elements are kept in the order they were added unless explicitly inserted or moved, and elements of the same region
+// are always rendered in this order. Keys identify elements for the operations below and are unique within the
+// frame; elements added with AppendModel/AppendModelTo have no key. A key is released once its element is pruned
+// (see TerminalElement and ImprintableElement).
+
+// AppendKeyed adds a UI element to the body region, identified by the given key.
+func (f *Frame) AppendKeyed(key string, uiElement tea.Model) error {
+	return f.AppendKeyedTo(RegionBody, key, uiElement)
+}
+
+// AppendKeyedTo adds a UI element to the given region, identified by the given key.
+func (f *Frame) AppendKeyedTo(region Region, key string, uiElement tea.Model) error {
+	return f.insert(len(f.models), annotatedModel{model: uiElement, region: region, key: key})
+}
+
+// InsertAt adds a UI element to the body region at the given position among all elements (clamped to the bounds of
+// the frame), identified by the given key.
+func (f *Frame) InsertAt(index int, key string, uiElement tea.Model) error {
+	return f.insert(index, annotatedModel{model: uiElement, region: RegionBody, key: key})
+}
+
+// InsertAfter adds a UI element immediately after the element with the given key (and to the same region), identified
+// by the given key.
+func (f *Frame) InsertAfter(after, key string, uiElement tea.Model) error {
+	i := f.indexOf(after)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrKeyNotFound, after)
+	}
+	return f.insert(i+1, annotatedModel{model: uiElement, region: f.models[i].region, key: key})
+}
+
+// Remove removes the element with the given key, returning false if there is no such element. The element is released
+// (see ReleasableElement).
+func (f *Frame) Remove(key string) bool {
+	i := f.indexOf(key)
+	if i < 0 {
+		return false
+	}
+	release(f.models[i].model)
+	f.models = append(f.models[:i], f.models[i+1:]...)
+	return true
+}
+
+// Replace swaps the element with the given key for another, keeping its key, region and position (e.g. to replace
+// a placeholder once the real element is available). The element being replaced is released (see ReleasableElement),
+// so it should not be part of the element that replaces it.
+func (f *Frame) Replace(key string, uiElement tea.Model) error {
+	i := f.indexOf(key)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrKeyNotFound, key)
+	}
+	release(f.models[i].model)
+	f.models[i].model = uiElement
+	f.models[i].hidden = isHidden(uiElement)
+	f.initModel(uiElement)
+	return nil
+}
+
+// Move repositions the element with the given key to the given position among all elements (clamped to the bounds of
+// the frame).
+func (f *Frame) Move(key string, index int) error {
+	i := f.indexOf(key)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrKeyNotFound, key)
+	}
+	el := f.models[i]
+	f.models = append(f.models[:i], f.models[i+1:]...)
+	f.models = insertAt(f.models, index, el)
+	return nil
+}
+
+// Model returns the element with the given key.
+func (f Frame) Model(key string) (tea.Model, bool) {
+	i := f.indexOf(key)
+	if i < 0 {
+		return nil, false
+	}
+	return f.models[i].model, true
+}
+
+// Index returns the position of the element with the given key among all elements, or -1 if there is no such element.
+func (f Frame) Index(key string) int {
+	return f.indexOf(key)
+}
+
+// Keys returns the keys of all keyed elements in order.
+func (f Frame) Keys() []string {
+	var keys []string
+	for _, el := range f.models {
+		if el.key != "" && !el.expired {
+			keys = append(keys, el.key)
+		}
+	}
+	return keys
+}
+
+func (f *Frame) insert(index int, el annotatedModel) error {
+	if el.key == "" {
+		return ErrEmptyKey
+	}
+	if f.indexOf(el.key) >= 0 {
+		return fmt.Errorf("%w: %q", ErrDuplicateKey, el.key)
+	}
+	el.hidden = isHidden(el.model)
+	f.models = insertAt(f.models, index, el)
+	f.initModel(el.model)
+	return nil
+}
+
+// indexOf returns the position of the element with the given key. Elements that have been imprinted (and are about to
+// be pruned) are no longer considered part of the frame.
+func (f Frame) indexOf(key string) int {
+	if key == "" {
+		return -1
+	}
+	for i, el := range f.models {
+		if el.key == key && !el.expired {
+			return i
+		}
+	}
+	return -1
+}
+
+func insertAt(models []annotatedModel, index int, el annotatedModel) []annotatedModel {
+	index = min(max(index, 0), len(models))
+	models = append(models, annotatedModel{})
+	copy(models[index+1:], models[index:])
+	models[index] = el
+	return models
+}
+
+func release(uiElement tea.Model) {
+	if r, ok := uiElement.(ReleasableElement); ok {
+		r.Release()
+	}
+}
+
+func isHidden(uiElement tea.Model) bool {
+	p, ok := uiElement.(VisibleElement)
+	return ok && p.IsHidden()
+}
@@ -0,0 +1,237 @@
+package frame
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keyedSubject returns a frame with an element for each key, whose view is the key itself.
+func keyedSubject(t *testing.T, keys ...string) *Frame {
+	t.Helper()
+	f := New()
+	for _, k := range keys {
+		require.NoError(t, f.AppendKeyed(k, mockModel{view: k}))
+	}
+	return f
+}
+
+func TestFrame_KeyedOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []string
+		op       func(f *Frame) error
+		wantErr  error
+		wantKeys []string
+		wantView string
+	}{
+		{
+			name:     "append",
+			keys:     []string{"a", "b"},
+			op:       func(f *Frame) error { return f.AppendKeyed("c", mockModel{view: "c"}) },
+			wantKeys: []string{"a", "b", "c"},
+			wantView: "a\nb\nc",
+		},
+		{
+			name:     "append duplicate key",
+			keys:     []string{"a", "b"},
+			op:       func(f *Frame) error { return f.AppendKeyed("a", mockModel{view: "dup"}) },
+			wantErr:  ErrDuplicateKey,
+			wantKeys: []string{"a", "b"},
+			wantView: "a\nb",
+		},
+		{
+			name:     "append empty key",
+			keys:     []string{"a"},
+			op:       func(f *Frame) error { return f.AppendKeyed("", mockModel{view: "empty"}) },
+			wantErr:  ErrEmptyKey,
+			wantKeys: []string{"a"},
+			wantView: "a",
+		},
+		{
+			name:     "insert at",
+			keys:     []string{"a", "b"},
+			op:       func(f *Frame) error { return f.InsertAt(1, "c", mockModel{view: "c"}) },
+			wantKeys: []string{"a", "c", "b"},
+			wantView: "a\nc\nb",
+		},
+		{
+			name:     "insert at is clamped",
+			keys:     []string{"a", "b"},
+			op:       func(f *Frame) error { return f.InsertAt(-5, "c", mockModel{view: "c"}) },
+			wantKeys: []string{"c", "a", "b"},
+			wantView: "c\na\nb",
+		},
+		{
+			name:     "insert after",
+			keys:     []string{"a", "b"},
+			op:       func(f *Frame) error { return f.InsertAfter("a", "c", mockModel{view: "c"}) },
+			wantKeys: []string{"a", "c", "b"},
+			wantView: "a\nc\nb",
+		},
+		{
+			name:     "insert after missing key",
+			keys:     []string{"a"},
+			op:       func(f *Frame) error { return f.InsertAfter("z", "c", mockModel{view: "c"}) },
+			wantErr:  ErrKeyNotFound,
+			wantKeys: []string{"a"},
+			wantView: "a",
+		},
+		{
+			name: "remove",
+			keys: []string{"a", "b", "c"},
+			op: func(f *Frame) error {
+				assert.True(t, f.Remove("b"))
+				assert.False(t, f.Remove("b"))
+				return nil
+			},
+			wantKeys: []string{"a", "c"},
+			wantView: "a\nc",
+		},
+		{
+			name:     "replace",
+			keys:     []string{"a", "b", "c"},
+			op:       func(f *Frame) error { return f.Replace("b", mockModel{view: "B"}) },
+			wantKeys: []string{"a", "b", "c"},
+			wantView: "a\nB\nc",
+		},
+		{
+			name:     "replace with hidden element",
+			keys:     []string{"a", "b"},
+			op:       func(f *Frame) error { return f.Replace("b", mockVisibleElement{isHidden: true}) },
+			wantKeys: []string{"a", "b"},
+			wantView: "a",
+		},
+		{
+			name:     "replace missing key",
+			keys:     []string{"a"},
+			op:       func(f *Frame) error { return f.Replace("z", mockModel{view: "z"}) },
+			wantErr:  ErrKeyNotFound,
+			wantKeys: []string{"a"},
+			wantView: "a",
+		},
+		{
+			name:     "move",
+			keys:     []string{"a", "b", "c"},
+			op:       func(f *Frame) error { return f.Move("a", 2) },
+			wantKeys: []string{"b", "c", "a"},
+			wantView: "b\nc\na",
+		},
+		{
+			name:     "move is clamped",
+			keys:     []string{"a", "b", "c"},
+			op:       func(f *Frame) error { return f.Move("c", -1) },
+			wantKeys: []string{"c", "a", "b"},
+			wantView: "c\na\nb",
+		},
+		{
+			name:     "move missing key",
+			keys:     []string{"a", "b"},
+			op:       func(f *Frame) error { return f.Move("z", 0) },
+			wantErr:  ErrKeyNotFound,
+			wantKeys: []string{"a", "b"},
+			wantView: "a\nb",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := keyedSubject(t, tt.keys...)
+
+			err := tt.op(f)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantKeys, f.Keys())
+			assert.Equal(t, tt.wantView, f.View())
+		})
+	}
+}
+
+func TestFrame_Model(t *testing.T) {
+	f := keyedSubject(t, "a", "b")
+
+	m, ok := f.Model("b")
+	require.True(t, ok)
+	assert.Equal(t, "b", m.View())
+	assert.Equal(t, 1, f.Index("b"))
+
+	_, ok = f.Model("z")
+	assert.False(t, ok)
+	assert.Equal(t, -1, f.Index("z"))
+}
+
+func TestFrame_Keyed_StableOrdering(t *testing.T) {
+	f := New()
+	f.AppendModel(mockModel{view: "unkeyed 1"})
+	require.NoError(t, f.AppendKeyedTo(RegionHeader, "header", mockModel{view: "header"}))
+	require.NoError(t, f.AppendKeyed("a", mockModel{view: "a"}))
+	f.AppendModel(mockModel{view: "unkeyed 2"})
+	require.NoError(t, f.InsertAfter("header", "subheader", mockModel{view: "subheader"}))
+
+	// updates (and the keyed operations of other elements) do not reorder elements
+	_, _ = f.Update(nil)
+	require.NoError(t, f.Replace("a", mockModel{view: "A"}))
+	_, _ = f.Update(nil)
+
+	assert.Equal(t, []string{"header", "subheader", "a"}, f.Keys())
+	assert.Equal(t, "header\nsubheader\nunkeyed 1\nA\nunkeyed 2", f.View())
+}
+
+func TestFrame_Keyed_PrunedElementsReleaseTheirKey(t *testing.T) {
+	f := New()
+	require.NoError(t, f.AppendKeyed("a", mockImprintableElement{mockModel: mockModel{view: "a"}, shouldImprint: true}))
+
+	// once imprinted the element is no longer part of the frame
+	_, _ = f.Update(nil)
+	assert.Empty(t, f.Keys())
+
+	require.NoError(t, f.AppendKeyed("a", mockModel{view: "again"}))
+	_, _ = f.Update(nil)
+
+	assert.Equal(t, []string{"a"}, f.Keys())
+	assert.Equal(t, "again", f.View())
+}
+
+type mockReleasableElement struct {
+	mockTerminalElement
+	released *int
+}
+
+func (m mockReleasableElement) Update(_ tea.Msg) (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func (m mockReleasableElement) Release() {
+	*m.released++
+}
+
+func TestFrame_ReleasesDroppedElements(t *testing.T) {
+	var released int
+	releasable := func(view string, isAlive bool) tea.Model {
+		return mockReleasableElement{
+			mockTerminalElement: mockTerminalElement{mockModel: mockModel{view: view}, isAlive: isAlive},
+			released:            &released,
+		}
+	}
+
+	f := New()
+	require.NoError(t, f.AppendKeyed("a", releasable("a", true)))
+	require.NoError(t, f.AppendKeyed("b", releasable("b", true)))
+	f.AppendModel(releasable("c", false))
+	assert.Zero(t, released)
+
+	require.True(t, f.Remove("a"))
+	assert.Equal(t, 1, released)
+
+	require.NoError(t, f.Replace("b", mockModel{view: "B"}))
+	assert.Equal(t, 2, released)
+
+	// pruned elements are released too
+	_, _ = f.Update(nil)
+	assert.Equal(t, 3, released)
+	assert.Equal(t, "B", f.View())
+}
@@ -0,0 +1,275 @@
+package frame
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var _ slog.Handler = (*LogHandler)(nil)
+
+// LogStyles are the styles used when rendering log records in the footer.
+type LogStyles struct {
+	Debug lipgloss.Style
+	Info  lipgloss.Style
+	Warn  lipgloss.Style
+	Error lipgloss.Style
+	Key   lipgloss.Style
+	Value lipgloss.Style
+}
+
+func DefaultLogStyles() LogStyles {
+	return LogStyles{
+		Debug: lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")),
+		Info:  lipgloss.NewStyle().Foreground(lipgloss.Color("12")), // 12 = high intensity blue (ANSI 16 bit color code)
+		Warn:  lipgloss.NewStyle().Foreground(lipgloss.Color("11")), // 11 = high intensity yellow (ANSI 16 bit color code)
+		Error: lipgloss.NewStyle().Foreground(lipgloss.Color("9")),  // 9 = high intensity red (ANSI 16 bit color code)
+		Key:   lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")),
+		Value: lipgloss.NewStyle(),
+	}
+}
+
+// LogOption configures a LogHandler.
+type LogOption func(*LogHandler)
+
+// WithLogLevel sets the minimum level of records written. Pass a *slog.LevelVar to change the level while the UI is
+// running (e.g. to toggle debug logging with a keybinding).
+func WithLogLevel(level slog.Leveler) LogOption {
+	return func(h *LogHandler) {
+		h.level = level
+	}
+}
+
+func WithLogStyles(styles LogStyles) LogOption {
+	return func(h *LogHandler) {
+		h.styles = styles
+	}
+}
+
+func WithLogNoStyle() LogOption {
+	return func(h *LogHandler) {
+		h.styles = LogStyles{}
+	}
+}
+
+// LogHandler is a slog.Handler that writes each record as a single styled line (e.g. "INFO  message key=value") to a
+// writer, typically the frame footer (see Frame.LogHandler).
+type LogHandler struct {
+	out    io.Writer
+	level  slog.Leveler
+	styles LogStyles
+	attrs  string // attributes from WithAttrs, already formatted
+	group  string // the prefix for attribute keys, from WithGroup
+}
+
+func NewLogHandler(w io.Writer, opts ...LogOption) *LogHandler {
+	h := &LogHandler{
+		out:    w,
+		level:  slog.LevelInfo,
+		styles: DefaultLogStyles(),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// LogHandler returns a slog.Handler that writes to the footer of the frame.
+func (f *Frame) LogHandler(opts ...LogOption) *LogHandler {
+	return NewLogHandler(f.Footer(), opts...)
+}
+
+func (h *LogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minimum := slog.LevelInfo
+	if h.level != nil {
+		minimum = h.level.Level()
+	}
+	return level >= minimum
+}
+
+func (h *LogHandler) Handle(_ context.Context, r slog.Record) error {
+	var sb strings.Builder
+	sb.WriteString(h.levelStyle(r.Level).Render(fmt.Sprintf("%-5s", levelLabel(r.Level))))
+	sb.WriteString(" ")
+	sb.WriteString(formatMessage(r.Message))
+	sb.WriteString(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		h.appendAttr(&sb, h.group, a)
+		return true
+	})
+	sb.WriteString("\n")
+
+	// a single write keeps concurrent records from interleaving
+	_, err := io.WriteString(h.out, sb.String())
+	return err
+}
+
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	var sb strings.Builder
+	sb.WriteString(h.attrs)
+	for _, a := range attrs {
+		h.appendAttr(&sb, h.group, a)
+	}
+
+	c := *h
+	c.attrs = sb.String()
+	return &c
+}
+
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.group = h.group + name + "."
+	return &c
+}
+
+func (h *LogHandler) appendAttr(sb *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		attrs := a.Value.Group()
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range attrs {
+			h.appendAttr(sb, prefix, ga)
+		}
+		return
+	}
+
+	sb.WriteString(" ")
+	sb.WriteString(h.styles.Key.Render(prefix + a.Key + "="))
+	sb.WriteString(h.styles.Value.Render(formatValue(a.Value)))
+}
+
+func (h *LogHandler) levelStyle(level slog.Level) lipgloss.Style {
+	switch {
+	case level >= slog.LevelError:
+		return h.styles.Error
+	case level >= slog.LevelWarn:
+		return h.styles.Warn
+	case level >= slog.LevelInfo:
+		return h.styles.Info
+	}
+	return h.styles.Debug
+}
+
+func levelLabel(level slog.Level) string {
+	// slog.Level.String reports levels between the named levels as offsets (e.g. "INFO+2"), but there is little room
+	// in a footer line, so these are reported as the named level beneath them
+	switch {
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARN"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	}
+	return "DEBUG"
+}
+
+// formatMessage renders the message of a record, quoting it if it would otherwise span several lines (or contains
+// other control characters), keeping each record on a single line.
+func formatMessage(msg string) string {
+	if strings.ContainsFunc(msg, func(r rune) bool { return !unicode.IsPrint(r) }) {
+		return strconv.Quote(msg)
+	}
+	return msg
+}
+
+// formatValue renders the value of an attribute, quoting it if it would otherwise be ambiguous.
+func formatValue(v slog.Value) string {
+	var s string
+	switch v.Kind() {
+	case slog.KindString:
+		s = v.String()
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			s = err.Error()
+		} else {
+			s = v.String()
+		}
+	default:
+		return v.String()
+	}
+
+	if needsQuoting(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if unicode.IsSpace(r) || r == '"' || r == '=' || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// levelWriter adapts the handler into an io.Writer, logging each line written as a record at a fixed level.
+type levelWriter struct {
+	handler slog.Handler
+	level   slog.Level
+	lock    *sync.Mutex
+	partial []byte
+}
+
+// NewLevelWriter returns an io.Writer that logs each line written to it as a record at the given level (e.g. for
+// libraries that only accept an io.Writer). Lines are filtered by the level of the handler as any other record, and
+// an unterminated line is held until the rest of it is written.
+func NewLevelWriter(handler slog.Handler, level slog.Level) io.Writer {
+	return &levelWriter{
+		handler: handler,
+		level:   level,
+		lock:    &sync.Mutex{},
+	}
+}
+
+func (w *levelWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	data := p
+	for {
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			w.partial = append(w.partial, data...)
+			break
+		}
+		line := string(append(w.partial, data[:idx]...))
+		w.partial = w.partial[:0]
+		data = data[idx+1:]
+
+		line = strings.TrimRight(line, "\r")
+		if line == "" || !w.handler.Enabled(context.Background(), w.level) {
+			continue
+		}
+		r := slog.NewRecord(time.Now(), w.level, line, 0)
+		if err := w.handler.Handle(context.Background(), r); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
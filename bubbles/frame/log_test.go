@@ -0,0 +1,113 @@
+package frame
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(l *slog.Logger)
+		want string
+	}{
+		{
+			name: "levels",
+			log: func(l *slog.Logger) {
+				l.Info("info")
+				l.Warn("warn")
+				l.Error("error")
+				l.Log(context.Background(), slog.LevelInfo+2, "between levels")
+			},
+			want: "INFO  info\nWARN  warn\nERROR error\nINFO  between levels\n",
+		},
+		{
+			name: "messages are kept to a single line",
+			log: func(l *slog.Logger) {
+				l.Info("first line\nsecond line")
+				l.Info("with\ttab")
+			},
+			want: "INFO  \"first line\\nsecond line\"\nINFO  \"with\\ttab\"\n",
+		},
+		{
+			name: "filtered by level",
+			log: func(l *slog.Logger) {
+				l.Debug("hidden")
+				l.Info("shown")
+			},
+			want: "INFO  shown\n",
+		},
+		{
+			name: "attributes",
+			log: func(l *slog.Logger) {
+				l.Info("resolved", "path", "/etc/os-release", "count", 3, "msg", "with spaces", "empty", "", "err", errors.New("not found"))
+			},
+			want: "INFO  resolved path=/etc/os-release count=3 msg=\"with spaces\" empty=\"\" err=\"not found\"\n",
+		},
+		{
+			name: "groups",
+			log: func(l *slog.Logger) {
+				l.With("cataloger", "apk").WithGroup("pkg").Info("found", "name", "musl", slog.Group("loc", "path", "/lib/apk/db/installed"))
+			},
+			want: "INFO  found cataloger=apk pkg.name=musl pkg.loc.path=/lib/apk/db/installed\n",
+		},
+		{
+			name: "empty attributes are ignored",
+			log: func(l *slog.Logger) {
+				l.Info("message", slog.Attr{}, slog.Group("empty"))
+			},
+			want: "INFO  message\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			tt.log(slog.New(NewLogHandler(buf, WithLogNoStyle())))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestLogHandler_RuntimeLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	level := &slog.LevelVar{}
+	l := slog.New(NewLogHandler(buf, WithLogNoStyle(), WithLogLevel(level)))
+
+	l.Debug("hidden")
+	level.Set(slog.LevelDebug)
+	l.Debug("shown")
+	level.Set(slog.LevelError)
+	l.Warn("hidden")
+
+	assert.Equal(t, "DEBUG shown\n", buf.String())
+}
+
+func TestFrame_LogHandler(t *testing.T) {
+	f := New()
+	f.AppendModel(mockModel{view: "visible"})
+
+	l := slog.New(f.LogHandler(WithLogNoStyle()))
+	l.Info("cataloging", "packages", 42)
+
+	assert.Equal(t, "visible\nINFO  cataloging packages=42", f.View())
+}
+
+func TestLevelWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	level := &slog.LevelVar{}
+	h := NewLogHandler(buf, WithLogNoStyle(), WithLogLevel(level))
+
+	w := NewLevelWriter(h, slog.LevelDebug)
+	_, _ = w.Write([]byte("hidden\n"))
+
+	level.Set(slog.LevelDebug)
+	_, _ = w.Write([]byte("first\r\nsec"))
+	_, _ = w.Write([]byte("ond\n\n"))
+
+	assert.Equal(t, "DEBUG first\nDEBUG second\n", buf.String())
+}
@@ -0,0 +1,194 @@
+package frame
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Region is a named area of the frame. Regions are rendered top to bottom in the order they are declared.
+type Region int
+
+const (
+	// RegionHeader is pinned to the top of the frame (e.g. the tool name and version).
+	RegionHeader Region = iota
+
+	// RegionBody holds the bulk of the elements (e.g. tasks). This is the region that AppendModel adds to, and the
+	// region that scrolls when the frame is in viewport mode.
+	RegionBody
+
+	// RegionStatus is a status bar beneath the body (e.g. key hints).
+	RegionStatus
+
+	// RegionFooter holds the log output written to Frame.Footer, after any elements appended to it.
+	RegionFooter
+)
+
+var regions = []Region{RegionHeader, RegionBody, RegionStatus, RegionFooter}
+
+func (r Region) String() string {
+	switch r {
+	case RegionHeader:
+		return "header"
+	case RegionBody:
+		return "body"
+	case RegionStatus:
+		return "status"
+	case RegionFooter:
+		return "footer"
+	}
+	return fmt.Sprintf("Region(%d)", int(r))
+}
+
+// Budget bounds the number of rows a region is given when the window is not tall enough for everything.
+type Budget struct {
+	// Min is the number of rows reserved for the region before any other region is given more than its minimum
+	// (never more than the region needs).
+	Min int
+	// Max is the most rows the region will be given (zero is unbounded).
+	Max int
+	// Priority orders which regions are given rows first when space is short (higher first).
+	Priority int
+}
+
+func defaultBudgets() map[Region]Budget {
+	return map[Region]Budget{
+		RegionHeader: {Priority: 3},
+		RegionStatus: {Priority: 2},
+		RegionBody:   {Priority: 1},
+		RegionFooter: {Priority: 0},
+	}
+}
+
+// AppendModelTo adds a UI element to the given region.
+func (f *Frame) AppendModelTo(region Region, uiElement tea.Model) {
+	f.models = append(f.models, annotatedModel{model: uiElement, region: region})
+	f.initModel(uiElement)
+}
+
+// SetRegionBudget bounds the number of rows the given region is given when space is short.
+func (f *Frame) SetRegionBudget(region Region, budget Budget) {
+	f.budgets[region] = budget
+}
+
+// regionContent renders the full (natural) content of a region.
+func (f Frame) regionContent(region Region) []string {
+	var lines []string
+	for _, b := range f.blocks(region) {
+		lines = append(lines, b.lines...)
+	}
+	if region == RegionFooter && f.showFooter {
+		for _, line := range f.footer.Lines() {
+			if len(line) > 0 {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
+
+// fitRegion fits the content of a region within the given number of rows.
+func (f Frame) fitRegion(region Region, lines []string, rows int) []string {
+	switch {
+	case region == RegionBody && !f.viewport.enabled:
+		// without a viewport the body is always rendered in full
+		return lines
+	case region == RegionFooter && !f.truncateFooter:
+		return lines
+	case rows <= 0:
+		return nil
+	}
+
+	switch region {
+	case RegionBody:
+		return strings.Split(f.renderViewport(f.blocks(RegionBody), rows), "\n")
+	case RegionFooter:
+		// the most recent log lines are the most relevant
+		return lines[max(len(lines)-rows, 0):]
+	}
+	return lines[:min(rows, len(lines))]
+}
+
+// allocate divides the window height between the regions, given how many rows each region needs.
+func (f Frame) allocate(needs map[Region]int) map[Region]int {
+	height := f.windowSize.Height
+
+	budgets := make(map[Region]Budget)
+	for r, b := range f.budgets {
+		budgets[r] = b
+	}
+	if !f.viewport.enabled {
+		// without a viewport the body is always rendered in full, so it cannot be capped
+		b := budgets[RegionBody]
+		b.Max = 0
+		budgets[RegionBody] = b
+	}
+
+	byPriority := append([]Region(nil), regions...)
+	sort.SliceStable(byPriority, func(i, j int) bool {
+		return budgets[byPriority[i]].Priority > budgets[byPriority[j]].Priority
+	})
+
+	wants := make(map[Region]int)
+	for _, r := range regions {
+		want := needs[r]
+		if b := budgets[r]; b.Max > 0 {
+			want = min(want, b.Max)
+		}
+		wants[r] = want
+	}
+
+	rows := make(map[Region]int)
+	remaining := height
+
+	// first honor the reserved rows...
+	for _, r := range byPriority {
+		n := min(budgets[r].Min, wants[r], remaining)
+		rows[r] = n
+		remaining -= n
+	}
+
+	// ...then give out what is left by priority
+	for _, r := range byPriority {
+		n := min(wants[r]-rows[r], remaining)
+		rows[r] += n
+		remaining -= n
+	}
+
+	return rows
+}
+
+// layout renders the content of all regions and (when the window height is known) allocates rows to each region.
+func (f Frame) layout() (map[Region][]string, map[Region]int) {
+	content := make(map[Region][]string)
+	needs := make(map[Region]int)
+	for _, r := range regions {
+		content[r] = f.regionContent(r)
+		needs[r] = len(content[r])
+	}
+
+	if f.windowSize.Height <= 0 {
+		return content, nil
+	}
+	return content, f.allocate(needs)
+}
+
+// render renders all regions, fitted to the window height when it is known.
+func (f Frame) render() string {
+	content, rows := f.layout()
+
+	var lines []string
+	for _, r := range regions {
+		if len(content[r]) == 0 {
+			continue
+		}
+		if rows == nil {
+			lines = append(lines, content[r]...)
+			continue
+		}
+		lines = append(lines, f.fitRegion(r, content[r], rows[r])...)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,118 @@
+package frame
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrame_allocate(t *testing.T) {
+	tests := []struct {
+		name     string
+		height   int
+		budgets  map[Region]Budget
+		viewport bool
+		needs    map[Region]int
+		want     map[Region]int
+	}{
+		{
+			name:     "everything fits",
+			height:   10,
+			viewport: true,
+			needs:    map[Region]int{RegionHeader: 1, RegionBody: 3, RegionStatus: 1, RegionFooter: 2},
+			want:     map[Region]int{RegionHeader: 1, RegionBody: 3, RegionStatus: 1, RegionFooter: 2},
+		},
+		{
+			name:     "pinned regions are given rows first",
+			height:   5,
+			viewport: true,
+			needs:    map[Region]int{RegionHeader: 1, RegionBody: 10, RegionStatus: 1, RegionFooter: 2},
+			want:     map[Region]int{RegionHeader: 1, RegionBody: 3, RegionStatus: 1, RegionFooter: 0},
+		},
+		{
+			name:     "reserved rows",
+			height:   5,
+			viewport: true,
+			budgets: map[Region]Budget{
+				RegionFooter: {Min: 2},
+			},
+			needs: map[Region]int{RegionHeader: 1, RegionBody: 10, RegionStatus: 1, RegionFooter: 5},
+			want:  map[Region]int{RegionHeader: 1, RegionBody: 1, RegionStatus: 1, RegionFooter: 2},
+		},
+		{
+			name:     "capped region leaves rows for others",
+			height:   8,
+			viewport: true,
+			budgets: map[Region]Budget{
+				RegionBody: {Max: 3, Priority: 1},
+			},
+			needs: map[Region]int{RegionBody: 10, RegionFooter: 10},
+			want:  map[Region]int{RegionHeader: 0, RegionBody: 3, RegionStatus: 0, RegionFooter: 5},
+		},
+		{
+			name:   "the body cannot be capped without a viewport",
+			height: 8,
+			budgets: map[Region]Budget{
+				RegionBody: {Max: 3, Priority: 1},
+			},
+			needs: map[Region]int{RegionBody: 6, RegionFooter: 10},
+			want:  map[Region]int{RegionHeader: 0, RegionBody: 6, RegionStatus: 0, RegionFooter: 2},
+		},
+		{
+			name:     "custom priority",
+			height:   4,
+			viewport: true,
+			budgets: map[Region]Budget{
+				RegionFooter: {Priority: 10},
+			},
+			needs: map[Region]int{RegionHeader: 1, RegionBody: 10, RegionFooter: 2},
+			want:  map[Region]int{RegionHeader: 1, RegionBody: 1, RegionStatus: 0, RegionFooter: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New()
+			f.Viewport(tt.viewport)
+			f.windowSize = tea.WindowSizeMsg{Height: tt.height}
+			for r, b := range tt.budgets {
+				f.SetRegionBudget(r, b)
+			}
+			assert.Equal(t, tt.want, f.allocate(tt.needs))
+		})
+	}
+}
+
+func TestFrame_View_Regions(t *testing.T) {
+	f := New()
+	f.Viewport(true)
+
+	// regions are rendered in order, regardless of the order elements are added
+	f.AppendModelTo(RegionStatus, mockModel{view: "q quit"})
+	f.AppendModelTo(RegionHeader, mockModel{view: "tool v1.0.0"})
+	for _, v := range []string{"c1", "c2", "c3", "c4"} {
+		f.AppendModel(completed(v))
+	}
+	f.AppendModel(running("r1"))
+	_, _ = f.Footer().Write([]byte("log 1\nlog 2\n"))
+
+	f.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	assert.Equal(t, "tool v1.0.0\nc1\nc2\nc3\nc4\nr1\nq quit\nlog 1\nlog 2", f.View())
+
+	f.Update(tea.WindowSizeMsg{Width: 80, Height: 6})
+	assert.Equal(t, "tool v1.0.0\n… and 2 more completed\nc3\nc4\nr1\nq quit", f.View())
+
+	f.SetRegionBudget(RegionFooter, Budget{Min: 1})
+	assert.Equal(t, "tool v1.0.0\n… and 3 more completed\nc4\nr1\nq quit\nlog 2", f.View())
+}
+
+func TestFrame_View_RegionsUnknownHeight(t *testing.T) {
+	f := New()
+	f.Viewport(true)
+	f.AppendModelTo(RegionHeader, mockModel{view: "header"})
+	f.AppendModel(completed("c1"))
+	f.AppendModel(completed("c2"))
+	_, _ = f.Footer().Write([]byte("log 1\n"))
+
+	assert.Equal(t, "header\nc1\nc2\nlog 1", f.View())
+}
@@ -0,0 +1,44 @@
+package frame
+
+import (
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// transcript is a persistent record of what was shown: everything imprinted to the scrollback and the final frame.
+type transcript struct {
+	out       io.Writer
+	stripANSI bool
+	err       error // the first error writing to out
+}
+
+// SetTranscript writes every imprinted element (see ImprintableElement) to the given writer (e.g. a log file) as it is
+// imprinted, optionally stripped of ANSI escape sequences (colors, styles). Call WriteFinalFrame once the program has
+// exited to complete the transcript. Pass nil to stop.
+func (f *Frame) SetTranscript(w io.Writer, stripANSI bool) {
+	f.transcript = transcript{out: w, stripANSI: stripANSI}
+}
+
+// WriteFinalFrame writes the last rendered view of the frame to the transcript (if any), returning the first error
+// encountered writing to the transcript.
+func (f *Frame) WriteFinalFrame() error {
+	f.transcript.write(f.View())
+	return f.transcript.err
+}
+
+func (t *transcript) write(view string) {
+	if t.out == nil || len(view) == 0 {
+		return
+	}
+	if t.stripANSI {
+		view = ansi.Strip(view)
+	}
+	if !strings.HasSuffix(view, "\n") {
+		view += "\n"
+	}
+	if _, err := io.WriteString(t.out, view); err != nil && t.err == nil {
+		t.err = err
+	}
+}
@@ -0,0 +1,62 @@
+package frame
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrame_Transcript(t *testing.T) {
+	styled := "\x1b[1mimprinted\x1b[0m"
+
+	tests := []struct {
+		name      string
+		stripANSI bool
+		want      string
+	}{
+		{
+			name:      "ansi stripped",
+			stripANSI: true,
+			want:      "imprinted\nfinal\n",
+		},
+		{
+			name: "ansi kept",
+			want: styled + "\nfinal\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			f := New()
+			f.SetTranscript(out, tt.stripANSI)
+			f.AppendModel(mockImprintableElement{mockModel: mockModel{view: styled}, shouldImprint: true})
+			f.AppendModel(mockModel{view: "final"})
+
+			_, cmd := f.Update(nil)
+			require.NotNil(t, cmd)
+			_, _ = f.Update(nil)
+
+			require.NoError(t, f.WriteFinalFrame())
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestFrame_Transcript_Error(t *testing.T) {
+	f := New()
+	f.SetTranscript(failingWriter{}, true)
+	f.AppendModel(mockImprintableElement{mockModel: mockModel{view: "imprinted"}, shouldImprint: true})
+
+	_, _ = f.Update(nil)
+
+	assert.ErrorContains(t, f.WriteFinalFrame(), "disk full")
+}
+
+func TestFrame_Transcript_Unset(t *testing.T) {
+	f := New()
+	f.AppendModel(mockModel{view: "final"})
+
+	assert.NoError(t, f.WriteFinalFrame())
+}
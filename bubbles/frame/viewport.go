@@ -0,0 +1,215 @@
+package frame
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CompletableElement allows UI elements to report that they have finished, so that when space is short (see
+// Frame.Viewport) elements that finished successfully can be collapsed in favor of those that are still running or
+// that did not succeed (e.g. failed, canceled or finished with warnings).
+type CompletableElement interface {
+	IsCompleted() bool
+	IsFailed() bool
+	IsSucceeded() bool
+}
+
+// ViewportKeyMap are the keybindings used to scroll through all elements when the frame is in viewport mode.
+type ViewportKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
+	Top      key.Binding
+	// Bottom stops scrolling, returning to following the most recent elements.
+	Bottom key.Binding
+}
+
+// DefaultViewportKeyMap returns keybindings that do not use any letter keys, so that typing still reaches elements
+// that accept text (e.g. a prompt).
+func DefaultViewportKeyMap() ViewportKeyMap {
+	return ViewportKeyMap{
+		Up:       key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "scroll up")),
+		Down:     key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "scroll down")),
+		PageUp:   key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "page up")),
+		PageDown: key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("pgdn", "page down")),
+		Top:      key.NewBinding(key.WithKeys("home"), key.WithHelp("home", "scroll to top")),
+		Bottom:   key.NewBinding(key.WithKeys("end"), key.WithHelp("end", "follow")),
+	}
+}
+
+type viewport struct {
+	enabled   bool
+	keys      ViewportKeyMap
+	scrolling bool
+	offset    int // the first line shown while scrolling
+}
+
+// block is the rendered view of a single element.
+type block struct {
+	lines []string
+	// collapsible blocks are for elements that succeeded, which can be summarized when space is short
+	collapsible bool
+}
+
+// Viewport keeps the rendered elements within the height of the window. When there are more lines than fit, finished
+// elements are collapsed (oldest first) into a summary line so that running and failed elements stay visible. The
+// full list can still be scrolled through with the keyboard (see SetViewportKeys).
+func (f *Frame) Viewport(set bool) {
+	f.viewport.enabled = set
+}
+
+func (f *Frame) SetViewportKeys(keys ViewportKeyMap) {
+	f.viewport.keys = keys
+}
+
+// handleViewportKey scrolls through the elements, returning false if the key is not a viewport key or there is nothing
+// to scroll through (in which case the key is left for the elements).
+func (f *Frame) handleViewportKey(msg tea.KeyMsg) bool {
+	if !f.viewport.enabled || f.windowSize.Height <= 0 {
+		return false
+	}
+	keys := f.viewport.keys
+	if !key.Matches(msg, keys.Up, keys.Down, keys.PageUp, keys.PageDown, keys.Top, keys.Bottom) {
+		return false
+	}
+
+	_, rows := f.layout()
+	height := rows[RegionBody]
+	last := max(countLines(f.blocks(RegionBody))-height, 0)
+	if last == 0 && !f.viewport.scrolling {
+		// everything fits, so there is nothing to scroll through
+		return false
+	}
+
+	var delta int
+	switch {
+	case key.Matches(msg, keys.Up):
+		delta = -1
+	case key.Matches(msg, keys.Down):
+		delta = 1
+	case key.Matches(msg, keys.PageUp):
+		delta = -max(height-1, 1)
+	case key.Matches(msg, keys.PageDown):
+		delta = max(height-1, 1)
+	case key.Matches(msg, keys.Top):
+		f.viewport.scrolling = true
+		f.viewport.offset = 0
+		return true
+	case key.Matches(msg, keys.Bottom):
+		f.viewport.scrolling = false
+		return true
+	}
+
+	if !f.viewport.scrolling {
+		// start scrolling from where the full list would end
+		f.viewport.offset = last
+	}
+	f.viewport.offset = min(max(f.viewport.offset+delta, 0), last)
+	// scrolling to the end returns to following the most recent elements
+	f.viewport.scrolling = f.viewport.offset < last
+
+	return true
+}
+
+// blocks renders all visible elements in the given region.
+func (f Frame) blocks(region Region) []block {
+	var blocks []block
+	for _, p := range f.models {
+		if p.hidden || p.region != region {
+			continue
+		}
+		rendered := p.model.View()
+		if len(rendered) == 0 {
+			continue
+		}
+		b := block{lines: strings.Split(rendered, "\n")}
+		if c, ok := p.model.(CompletableElement); ok {
+			b.collapsible = c.IsCompleted() && c.IsSucceeded()
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
+
+// renderViewport renders the elements within the given height.
+func (f Frame) renderViewport(blocks []block, height int) string {
+	if f.viewport.scrolling {
+		lines := joinBlocks(blocks)
+		start := min(f.viewport.offset, max(len(lines)-height, 0))
+		end := min(start+height, len(lines))
+		return strings.Join(lines[start:end], "\n")
+	}
+	return strings.Join(collapse(blocks, height), "\n")
+}
+
+// collapse fits the blocks within the given height. Finished elements are collapsed first (oldest first) into a
+// summary line, then (only if that is not enough) the oldest of the remaining elements.
+func collapse(blocks []block, height int) []string {
+	total := countLines(blocks)
+	if total <= height {
+		return joinBlocks(blocks)
+	}
+
+	hidden := make([]bool, len(blocks))
+	var collapsed, dropped int
+	for _, collapsibleOnly := range []bool{true, false} {
+		for i, b := range blocks {
+			if total+1 <= height {
+				break
+			}
+			if hidden[i] || collapsibleOnly && !b.collapsible {
+				continue
+			}
+			if !collapsibleOnly && i == len(blocks)-1 {
+				// always keep the most recent element, even if only partially
+				break
+			}
+			hidden[i] = true
+			total -= len(b.lines)
+			if b.collapsible {
+				collapsed++
+			} else {
+				dropped++
+			}
+		}
+	}
+
+	var visible []block
+	for i, b := range blocks {
+		if !hidden[i] {
+			visible = append(visible, b)
+		}
+	}
+
+	summary := fmt.Sprintf("… and %d more completed", collapsed)
+	if dropped > 0 {
+		summary = fmt.Sprintf("… and %d more", collapsed+dropped)
+	}
+
+	lines := joinBlocks(visible)
+	if over := len(lines) + 1 - height; over > 0 {
+		// a single element is taller than the window, keep the end of it
+		lines = lines[over:]
+	}
+	return append([]string{summary}, lines...)
+}
+
+func joinBlocks(blocks []block) []string {
+	var lines []string
+	for _, b := range blocks {
+		lines = append(lines, b.lines...)
+	}
+	return lines
+}
+
+func countLines(blocks []block) int {
+	var n int
+	for _, b := range blocks {
+		n += len(b.lines)
+	}
+	return n
+}
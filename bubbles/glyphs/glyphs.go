@@ -0,0 +1,161 @@
+package glyphs
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+)
+
+// EnvVar can be set to the name of a glyph set (e.g. "ascii") to override terminal detection.
+const EnvVar = "BUBBLY_GLYPHS"
+
+// Set is a named collection of the glyphs used across bubbles: spinner frames, state icons, progress bar characters
+// and tree connectors.
+type Set struct {
+	Name    string
+	Spinner spinner.Spinner
+
+	// state icons
+	Success  string
+	Failed   string
+	Warning  string
+	Pending  string
+	Canceled string
+
+	// progress bar characters
+	BarFull  rune
+	BarEmpty rune
+
+	// sparkline levels, from lowest to highest
+	Sparkline []string
+
+	// tree connectors
+	TreeIndent string
+	TreeBranch string
+	TreeFork   string
+	TreeLeaf   string
+}
+
+// spinner presets
+
+var (
+	// BrailleSpinner matches the same spinner as syft/grype.
+	BrailleSpinner = spinner.Spinner{
+		Frames: strings.Split("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏", ""),
+		FPS:    150 * time.Millisecond,
+	}
+	LineSpinner = spinner.Spinner{
+		Frames: []string{"|", "/", "-", "\\"},
+		FPS:    150 * time.Millisecond,
+	}
+	ClockSpinner = spinner.Spinner{
+		Frames: []string{"🕛", "🕐", "🕑", "🕒", "🕓", "🕔", "🕕", "🕖", "🕗", "🕘", "🕙", "🕚"},
+		FPS:    150 * time.Millisecond,
+	}
+)
+
+var (
+	unicodeSparkline = strings.Split("▁▂▃▄▅▆▇█", "")
+	asciiSparkline   = strings.Split("_.,-~=*#", "")
+)
+
+var (
+	// Unicode is the default set, which matches the look of syft/grype.
+	Unicode = Set{
+		Name:       "unicode",
+		Spinner:    BrailleSpinner,
+		Success:    "✔",
+		Failed:     "✘",
+		Warning:    "⚠",
+		Pending:    "•",
+		Canceled:   "⊘",
+		BarFull:    '━',
+		BarEmpty:   '━',
+		Sparkline:  unicodeSparkline,
+		TreeIndent: "   ",
+		TreeBranch: "│  ",
+		TreeFork:   "├──",
+		TreeLeaf:   "└──",
+	}
+
+	// ASCII is for terminals and log viewers that cannot render anything beyond 7-bit ASCII. Since colors may be
+	// lost as well, the full and empty parts of the progress bar use different characters.
+	ASCII = Set{
+		Name:       "ascii",
+		Spinner:    LineSpinner,
+		Success:    "+",
+		Failed:     "x",
+		Warning:    "!",
+		Pending:    "-",
+		Canceled:   "~",
+		BarFull:    '#',
+		BarEmpty:   '.',
+		Sparkline:  asciiSparkline,
+		TreeIndent: "   ",
+		TreeBranch: "|  ",
+		TreeFork:   "|--",
+		TreeLeaf:   "`--",
+	}
+
+	Emoji = Set{
+		Name:       "emoji",
+		Spinner:    ClockSpinner,
+		Success:    "✅",
+		Failed:     "❌",
+		Warning:    "🔶",
+		Pending:    "⏳",
+		Canceled:   "🚫",
+		BarFull:    '━',
+		BarEmpty:   '━',
+		Sparkline:  unicodeSparkline,
+		TreeIndent: "   ",
+		TreeBranch: "│  ",
+		TreeFork:   "├──",
+		TreeLeaf:   "└──",
+	}
+)
+
+// Named returns the glyph set with the given name (case-insensitive), or false if there is no such set.
+func Named(name string) (Set, bool) {
+	for _, s := range []Set{Unicode, ASCII, Emoji} {
+		if strings.EqualFold(strings.TrimSpace(name), s.Name) {
+			return s, true
+		}
+	}
+	return Set{}, false
+}
+
+// Detect returns the glyph set best suited for the current terminal. Detection is deliberately conservative: the
+// Unicode set is used unless the terminal is known to not support it (a "dumb" terminal, or a legacy Windows
+// console). The EnvVar environment variable can be used to select a set explicitly.
+func Detect() Set {
+	return detect(os.Getenv, runtime.GOOS)
+}
+
+func detect(getenv func(string) string, goos string) Set {
+	if s, ok := Named(getenv(EnvVar)); ok {
+		return s
+	}
+
+	if getenv("TERM") == "dumb" {
+		return ASCII
+	}
+
+	if goos == "windows" && !modernWindowsTerminal(getenv) {
+		return ASCII
+	}
+
+	return Unicode
+}
+
+// modernWindowsTerminal indicates that the process is running within a Windows terminal that renders Unicode (as
+// opposed to the legacy console host).
+func modernWindowsTerminal(getenv func(string) string) bool {
+	return getenv("WT_SESSION") != "" || // Windows Terminal
+		getenv("TERM_PROGRAM") != "" || // e.g. vscode
+		getenv("ConEmuANSI") == "ON" ||
+		getenv("TERM") != "" // e.g. mintty, cygwin, msys
+}
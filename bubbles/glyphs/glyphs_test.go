@@ -0,0 +1,86 @@
+package glyphs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamed(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   Set
+		wantOk bool
+	}{
+		{name: "unicode", want: Unicode, wantOk: true},
+		{name: "ASCII", want: ASCII, wantOk: true},
+		{name: " emoji ", want: Emoji, wantOk: true},
+		{name: "nerdfont"},
+		{name: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Named(tt.name)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		goos string
+		want string
+	}{
+		{
+			name: "typical linux terminal",
+			env:  map[string]string{"TERM": "xterm-256color"},
+			goos: "linux",
+			want: "unicode",
+		},
+		{
+			name: "no TERM outside of windows",
+			goos: "darwin",
+			want: "unicode",
+		},
+		{
+			name: "dumb terminal",
+			env:  map[string]string{"TERM": "dumb"},
+			goos: "linux",
+			want: "ascii",
+		},
+		{
+			name: "legacy windows console",
+			goos: "windows",
+			want: "ascii",
+		},
+		{
+			name: "windows terminal",
+			env:  map[string]string{"WT_SESSION": "e1b3c7a2"},
+			goos: "windows",
+			want: "unicode",
+		},
+		{
+			name: "explicit override wins",
+			env:  map[string]string{"TERM": "dumb", EnvVar: "emoji"},
+			goos: "linux",
+			want: "emoji",
+		},
+		{
+			name: "unknown override is ignored",
+			env:  map[string]string{EnvVar: "fancy"},
+			goos: "windows",
+			want: "ascii",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string {
+				return tt.env[key]
+			}
+			assert.Equal(t, tt.want, detect(getenv, tt.goos).Name)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package linebuffer
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Buffer is a concurrency-safe io.Writer that retains only the most recent lines written to it. The number of lines
+// and the number of bytes retained can each be capped (a cap of zero or less means unbounded). A trailing line that
+// has not been terminated with a newline yet is retained as well, so that partially written output is still visible.
+type Buffer struct {
+	lock     *sync.RWMutex
+	lines    []string
+	head     int // index of the oldest retained line
+	size     int // bytes held by retained (complete) lines, including newlines
+	partial  []byte
+	maxLines int
+	maxBytes int
+}
+
+// New returns a buffer that retains at most maxLines lines and maxBytes bytes.
+func New(maxLines, maxBytes int) *Buffer {
+	return &Buffer{
+		lock:     &sync.RWMutex{},
+		maxLines: maxLines,
+		maxBytes: maxBytes,
+	}
+}
+
+// Write appends to the buffer, evicting the oldest lines as needed to stay within the configured caps.
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	data := p
+	for {
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			b.partial = append(b.partial, data...)
+			break
+		}
+		line := string(append(b.partial, data[:idx]...))
+		b.partial = b.partial[:0]
+		data = data[idx+1:]
+		b.push(line)
+	}
+	b.trimPartial()
+
+	return len(p), nil
+}
+
+// Read consumes retained content, oldest first, returning io.EOF once there is nothing left to read.
+func (b *Buffer) Read(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	var n int
+	for n < len(p) && b.count() > 0 {
+		line := b.lines[b.head] + "\n"
+		c := copy(p[n:], line)
+		n += c
+		if c < len(line) {
+			// keep the unread remainder of the line (without the newline, which is implied)
+			b.lines[b.head] = line[c : len(line)-1]
+			b.size -= c
+			break
+		}
+		b.evict()
+	}
+
+	if n < len(p) && b.count() == 0 && len(b.partial) > 0 {
+		c := copy(p[n:], b.partial)
+		n += c
+		b.partial = append(b.partial[:0], b.partial[c:]...)
+	}
+
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// SetLimits changes the caps on the number of lines and bytes retained, evicting the oldest lines as needed.
+func (b *Buffer) SetLimits(maxLines, maxBytes int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.maxLines = maxLines
+	b.maxBytes = maxBytes
+	b.enforce()
+	b.trimPartial()
+}
+
+// Lines returns the retained lines (including any unterminated trailing line), oldest first.
+func (b *Buffer) Lines() []string {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	lines := append([]string(nil), b.lines[b.head:]...)
+	if len(b.partial) > 0 {
+		lines = append(lines, string(b.partial))
+	}
+	return lines
+}
+
+// String returns the retained contents as they were written.
+func (b *Buffer) String() string {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	var sb strings.Builder
+	for _, line := range b.lines[b.head:] {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	sb.Write(b.partial)
+	return sb.String()
+}
+
+// Len returns the number of bytes retained.
+func (b *Buffer) Len() int {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.size + len(b.partial)
+}
+
+// Reset discards all retained content.
+func (b *Buffer) Reset() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.lines = nil
+	b.head = 0
+	b.size = 0
+	b.partial = nil
+}
+
+func (b *Buffer) count() int {
+	return len(b.lines) - b.head
+}
+
+func (b *Buffer) push(line string) {
+	b.lines = append(b.lines, line)
+	b.size += len(line) + 1
+	b.enforce()
+}
+
+// enforce evicts the oldest lines until the complete lines are within the caps.
+func (b *Buffer) enforce() {
+	for b.count() > 0 && (b.maxLines > 0 && b.count() > b.maxLines || b.maxBytes > 0 && b.size > b.maxBytes) {
+		b.evict()
+	}
+}
+
+func (b *Buffer) evict() {
+	b.size -= len(b.lines[b.head]) + 1
+	b.lines[b.head] = ""
+	b.head++
+
+	// reclaim the space held by evicted lines once they make up most of the backing array
+	if b.head > 32 && b.head*2 > len(b.lines) {
+		b.lines = append([]string(nil), b.lines[b.head:]...)
+		b.head = 0
+	}
+}
+
+// trimPartial keeps an unterminated line within the byte cap by discarding its oldest bytes.
+func (b *Buffer) trimPartial() {
+	if b.maxBytes <= 0 {
+		return
+	}
+	for b.count() > 0 && b.size+len(b.partial) > b.maxBytes {
+		b.evict()
+	}
+	if over := len(b.partial) - b.maxBytes; over > 0 {
+		b.partial = append(b.partial[:0], b.partial[over:]...)
+	}
+}
@@ -0,0 +1,159 @@
+package linebuffer
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuffer_Write(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxLines  int
+		maxBytes  int
+		writes    []string
+		wantLines []string
+		wantStr   string
+	}{
+		{
+			name:      "unbounded",
+			writes:    []string{"a\nb\n", "c"},
+			wantLines: []string{"a", "b", "c"},
+			wantStr:   "a\nb\nc",
+		},
+		{
+			name:      "lines split across writes",
+			writes:    []string{"hel", "lo\nwor", "ld\n"},
+			wantLines: []string{"hello", "world"},
+			wantStr:   "hello\nworld\n",
+		},
+		{
+			name:      "line cap keeps the most recent lines",
+			maxLines:  2,
+			writes:    []string{"1\n2\n3\n", "4\n"},
+			wantLines: []string{"3", "4"},
+			wantStr:   "3\n4\n",
+		},
+		{
+			name:      "line cap does not count the unterminated line",
+			maxLines:  2,
+			writes:    []string{"1\n2\n3\n4"},
+			wantLines: []string{"2", "3", "4"},
+			wantStr:   "2\n3\n4",
+		},
+		{
+			name:      "byte cap",
+			maxBytes:  6,
+			writes:    []string{"aa\nbb\ncc\n"},
+			wantLines: []string{"bb", "cc"},
+			wantStr:   "bb\ncc\n",
+		},
+		{
+			name:      "byte cap applies to a long unterminated line",
+			maxBytes:  4,
+			writes:    []string{"aa\n", "123456"},
+			wantLines: []string{"3456"},
+			wantStr:   "3456",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.maxLines, tt.maxBytes)
+			for _, w := range tt.writes {
+				n, err := b.Write([]byte(w))
+				require.NoError(t, err)
+				require.Equal(t, len(w), n)
+			}
+			assert.Equal(t, tt.wantLines, b.Lines())
+			assert.Equal(t, tt.wantStr, b.String())
+			assert.Equal(t, len(tt.wantStr), b.Len())
+		})
+	}
+}
+
+func TestBuffer_EvictionReclaimsSpace(t *testing.T) {
+	b := New(10, 0)
+	for i := 0; i < 10_000; i++ {
+		_, _ = fmt.Fprintf(b, "line %d\n", i)
+	}
+
+	assert.Len(t, b.Lines(), 10)
+	assert.Equal(t, "line 9999", b.Lines()[9])
+	assert.Less(t, len(b.lines), 100)
+}
+
+func TestBuffer_Reset(t *testing.T) {
+	b := New(0, 0)
+	_, _ = b.Write([]byte("a\nb"))
+	b.Reset()
+
+	assert.Empty(t, b.Lines())
+	assert.Zero(t, b.Len())
+}
+
+func TestBuffer_ConcurrentAccess(t *testing.T) {
+	b := New(5, 0)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, _ = fmt.Fprintf(b, "line %d\n", j)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = b.String()
+				_ = b.Lines()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, b.Lines(), 5)
+}
+
+func TestBuffer_Read(t *testing.T) {
+	b := New(0, 0)
+	_, _ = b.Write([]byte("hello\nworld\npart"))
+
+	p := make([]byte, 3)
+	n, err := b.Read(p)
+	require.NoError(t, err)
+	assert.Equal(t, "hel", string(p[:n]))
+	assert.Equal(t, []string{"lo", "world", "part"}, b.Lines())
+
+	rest, err := io.ReadAll(b)
+	require.NoError(t, err)
+	assert.Equal(t, "lo\nworld\npart", string(rest))
+	assert.Empty(t, b.Lines())
+	assert.Zero(t, b.Len())
+
+	n, err = b.Read(p)
+	assert.Zero(t, n)
+	assert.ErrorIs(t, err, io.EOF)
+
+	// the buffer is still usable after being drained
+	_, _ = b.Write([]byte("again\n"))
+	assert.Equal(t, "again\n", b.String())
+}
+
+func TestBuffer_SetLimits(t *testing.T) {
+	b := New(0, 0)
+	_, _ = b.Write([]byte("1\n2\n3\n4\n5\n"))
+
+	b.SetLimits(3, 0)
+	assert.Equal(t, []string{"3", "4", "5"}, b.Lines())
+
+	b.SetLimits(0, 4)
+	assert.Equal(t, []string{"4", "5"}, b.Lines())
+
+	_, _ = b.Write([]byte("6\n"))
+	assert.Equal(t, []string{"5", "6"}, b.Lines())
+}
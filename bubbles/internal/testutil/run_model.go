@@ -36,6 +36,14 @@ func flatten(p tea.Msg) (msgs []tea.Msg) {
 	if p == nil {
 		return nil
 	}
+	if batch, ok := p.(tea.BatchMsg); ok {
+		for _, cmd := range batch {
+			if cmd != nil {
+				msgs = append(msgs, flatten(cmd())...)
+			}
+		}
+		return msgs
+	}
 	if reflect.TypeOf(p).Name() == "batchMsg" {
 		partials := extractBatchMessages(p)
 		for _, m := range partials {
@@ -63,3 +71,11 @@ func extractBatchMessages(m tea.Msg) (ret []tea.Msg) {
 	}
 	return ret
 }
+
+// Messages runs the given command (and any commands it batches), returning all resulting messages.
+func Messages(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	return flatten(cmd())
+}
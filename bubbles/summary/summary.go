@@ -0,0 +1,324 @@
+package summary
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/bubbly/bubbles/glyphs"
+	"github.com/anchore/bubbly/bubbles/taskprogress"
+)
+
+const columnGap = "  "
+
+// Row is the final (or current) report for a single task.
+type Row struct {
+	Title    string
+	State    taskprogress.State
+	Duration time.Duration
+	Current  int64
+	Size     int64
+	Stats    []string
+	Err      error
+}
+
+// Collector gathers the tasks of a run so that a single report can be rendered once the run finishes.
+type Collector struct {
+	lock    *sync.RWMutex
+	sources []func() Row
+
+	Glyphs glyphs.Set
+
+	HeaderStyle   lipgloss.Style
+	TitleStyle    lipgloss.Style
+	StatsStyle    lipgloss.Style
+	PendingStyle  lipgloss.Style
+	RunningStyle  lipgloss.Style
+	SuccessStyle  lipgloss.Style
+	WarningStyle  lipgloss.Style
+	FailedStyle   lipgloss.Style
+	CanceledStyle lipgloss.Style
+}
+
+func New() *Collector {
+	return &Collector{
+		lock:   &sync.RWMutex{},
+		Glyphs: glyphs.Detect(),
+
+		HeaderStyle:  lipgloss.NewStyle().Bold(true).Underline(true),
+		TitleStyle:   lipgloss.NewStyle().Bold(true),
+		StatsStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")),
+		PendingStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")),
+		RunningStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("13")), // 13 = high intentity magenta (ANSI 16 bit color code)
+		SuccessStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("10")), // 10 = high intensity green (ANSI 16 bit color code)
+		WarningStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("11")), // 11 = high intensity yellow (ANSI 16 bit color code)
+		FailedStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("9")),  // 9 = high intensity red (ANSI 16 bit color code)
+		CanceledStyle: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{
+			Light: "#555555",
+			Dark:  "#AAAAAA",
+		}),
+	}
+}
+
+// Observe adds the given task models to the report. Since models are values, the report follows each model through
+// its handle, so it reflects the latest state of the model regardless of which copy is being rendered.
+func (c *Collector) Observe(models ...taskprogress.Model) {
+	for _, m := range models {
+		c.ObserveHandle(m.Handle())
+	}
+}
+
+// ObserveHandle adds the task behind the given handle to the report.
+func (c *Collector) ObserveHandle(h *taskprogress.Handle) {
+	c.add(func() Row {
+		s := h.Status()
+		return Row{
+			Title:    s.Title,
+			State:    s.State,
+			Duration: s.Duration(),
+			Current:  s.Current,
+			Size:     s.Size,
+			Stats:    s.Hints,
+			Err:      s.Err,
+		}
+	})
+}
+
+// ObserveProgress adds a task that is not backed by a model to the report. If the progressor is also a
+// progress.Stager then the final stage is used as the stats for the task. Since progress does not carry any timing
+// information, no duration is reported for these tasks.
+func (c *Collector) ObserveProgress(title string, p progress.Progressable) {
+	c.add(func() Row {
+		current := progress.NewGenerator(p, p).Progress()
+		r := Row{
+			Title:   title,
+			State:   taskprogress.StateOf(current),
+			Current: current.Current(),
+			Size:    current.Size(),
+		}
+		if stager, ok := p.(progress.Stager); ok {
+			if stage := stager.Stage(); stage != "" {
+				r.Stats = []string{stage}
+			}
+		}
+		if r.State == taskprogress.StateFailed || r.State == taskprogress.StateWarning {
+			r.Err = current.Error()
+		}
+		return r
+	})
+}
+
+func (c *Collector) add(fn func() Row) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.sources = append(c.sources, fn)
+}
+
+// Rows returns the report for each observed task, in the order they were added.
+func (c *Collector) Rows() []Row {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	rows := make([]Row, 0, len(c.sources))
+	for _, fn := range c.sources {
+		rows = append(rows, fn())
+	}
+	return rows
+}
+
+// Imprint returns a command that prints the styled report above the program's output.
+func (c *Collector) Imprint() tea.Cmd {
+	return tea.Println(c.View())
+}
+
+// View returns the report as a styled table.
+func (c *Collector) View() string {
+	return c.render(c.Rows(), true)
+}
+
+// Text returns the report as a plain text table, suitable for logs or non-interactive terminals.
+func (c *Collector) Text() string {
+	return c.render(c.Rows(), false)
+}
+
+type jsonRow struct {
+	Title    string   `json:"title"`
+	Status   string   `json:"status"`
+	Duration float64  `json:"durationSeconds"`
+	Current  int64    `json:"current"`
+	Size     int64    `json:"size"`
+	Stats    []string `json:"stats,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// JSON returns the report as a JSON array, one object per task.
+func (c *Collector) JSON() ([]byte, error) {
+	rows := c.Rows()
+	out := make([]jsonRow, 0, len(rows))
+	for _, r := range rows {
+		jr := jsonRow{
+			Title:    r.Title,
+			Status:   r.State.String(),
+			Duration: r.Duration.Seconds(),
+			Current:  r.Current,
+			Size:     r.Size,
+			Stats:    r.Stats,
+		}
+		if r.Err != nil {
+			jr.Error = r.Err.Error()
+		}
+		out = append(out, jr)
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+func (c *Collector) render(rows []Row, styled bool) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	style := func(s lipgloss.Style, text string) string {
+		if !styled {
+			return text
+		}
+		return s.Render(text)
+	}
+
+	table := [][]string{{"STATUS", "TASK", "DURATION", "STATS"}}
+	for _, r := range rows {
+		table = append(table, []string{c.statusText(r.State, styled), r.Title, formatDuration(r.Duration), stats(r)})
+	}
+
+	widths := make([]int, len(table[0]))
+	for _, cells := range table {
+		for i, cell := range cells {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	var lines []string
+	for i, cells := range table {
+		var rendered []string
+		for j, cell := range cells {
+			// the last column is not padded to avoid trailing whitespace
+			if j < len(cells)-1 {
+				cell += strings.Repeat(" ", widths[j]-lipgloss.Width(cell))
+			}
+			switch {
+			case i == 0:
+				cell = style(c.HeaderStyle, cell)
+			case j == 0:
+				cell = style(c.stateStyle(rows[i-1].State), cell)
+			case j == 1:
+				cell = style(c.TitleStyle, cell)
+			case j == 3:
+				cell = style(c.StatsStyle, cell)
+			}
+			rendered = append(rendered, cell)
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(rendered, columnGap), " "))
+	}
+
+	lines = append(lines, "", totals(rows))
+
+	return strings.Join(lines, "\n")
+}
+
+func (c *Collector) stateStyle(state taskprogress.State) lipgloss.Style {
+	switch state {
+	case taskprogress.StatePending:
+		return c.PendingStyle
+	case taskprogress.StateSuccess:
+		return c.SuccessStyle
+	case taskprogress.StateWarning:
+		return c.WarningStyle
+	case taskprogress.StateFailed:
+		return c.FailedStyle
+	case taskprogress.StateCanceled:
+		return c.CanceledStyle
+	}
+	return c.RunningStyle
+}
+
+func (c *Collector) statusText(state taskprogress.State, styled bool) string {
+	if !styled {
+		return state.String()
+	}
+	var icon string
+	switch state {
+	case taskprogress.StatePending:
+		icon = c.Glyphs.Pending
+	case taskprogress.StateSuccess:
+		icon = c.Glyphs.Success
+	case taskprogress.StateWarning:
+		icon = c.Glyphs.Warning
+	case taskprogress.StateFailed:
+		icon = c.Glyphs.Failed
+	case taskprogress.StateCanceled:
+		icon = c.Glyphs.Canceled
+	default:
+		if len(c.Glyphs.Spinner.Frames) > 0 {
+			icon = c.Glyphs.Spinner.Frames[0]
+		}
+	}
+	return icon + " " + state.String()
+}
+
+func stats(r Row) string {
+	var parts []string
+	if len(r.Stats) > 0 {
+		parts = append(parts, strings.Join(r.Stats, " "))
+	} else if r.Size > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d", r.Current, r.Size))
+	}
+	if r.Err != nil && r.State != taskprogress.StateCanceled {
+		parts = append(parts, r.Err.Error())
+	}
+	return strings.Join(parts, "; ")
+}
+
+// totals summarizes how many tasks ended in each state, e.g. "3 tasks: 2 success, 1 failed".
+func totals(rows []Row) string {
+	counts := make(map[taskprogress.State]int)
+	for _, r := range rows {
+		counts[r.State]++
+	}
+
+	var parts []string
+	for _, state := range []taskprogress.State{
+		taskprogress.StateSuccess,
+		taskprogress.StateWarning,
+		taskprogress.StateFailed,
+		taskprogress.StateCanceled,
+		taskprogress.StateRetrying,
+		taskprogress.StateRunning,
+		taskprogress.StatePending,
+	} {
+		if n := counts[state]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, state))
+		}
+	}
+
+	noun := "tasks"
+	if len(rows) == 1 {
+		noun = "task"
+	}
+	return fmt.Sprintf("%d %s: %s", len(rows), noun, strings.Join(parts, ", "))
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Second:
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
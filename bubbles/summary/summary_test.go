@@ -0,0 +1,124 @@
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/bubbly/bubbles/glyphs"
+	"github.com/anchore/bubbly/bubbles/taskprogress"
+)
+
+type stagedProgress struct {
+	*progress.Manual
+	stage string
+}
+
+func (s stagedProgress) Stage() string {
+	return s.stage
+}
+
+func collector() *Collector {
+	c := New()
+	c.Glyphs = glyphs.Unicode
+	c.ObserveProgress("Catalog packages", stagedProgress{
+		Manual: &progress.Manual{N: 42, Total: 42, Err: progress.ErrCompleted},
+		stage:  "42 packages",
+	})
+	c.ObserveProgress("Download database", &progress.Manual{N: 10, Total: 10, Err: progress.ErrCompleted})
+	c.ObserveProgress("Scan files", &progress.Manual{N: 3, Total: 10, Err: taskprogress.CompletedWithWarnings(errors.New("2 files unreadable"))})
+	c.ObserveProgress("Upload results", &progress.Manual{N: 10, Total: 10, Err: errors.New("connection refused")})
+	c.ObserveProgress("Cleanup", &progress.Manual{N: 1, Total: 4})
+	c.ObserveProgress("Push image", &progress.Manual{N: 2, Total: 8, Err: context.Canceled})
+	return c
+}
+
+func TestCollector_Text(t *testing.T) {
+	got := collector().Text()
+	t.Log(got)
+	snaps.MatchSnapshot(t, got)
+}
+
+func TestCollector_View(t *testing.T) {
+	got := collector().View()
+	t.Log(got)
+	snaps.MatchSnapshot(t, got)
+}
+
+func TestCollector_Empty(t *testing.T) {
+	c := New()
+	assert.Empty(t, c.View())
+	assert.Empty(t, c.Text())
+
+	got, err := c.JSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, "[]", string(got))
+}
+
+func TestCollector_JSON(t *testing.T) {
+	got, err := collector().JSON()
+	require.NoError(t, err)
+
+	var rows []map[string]any
+	require.NoError(t, json.Unmarshal(got, &rows))
+	require.Len(t, rows, 6)
+
+	assert.Equal(t, "Catalog packages", rows[0]["title"])
+	assert.Equal(t, "success", rows[0]["status"])
+	assert.Equal(t, []any{"42 packages"}, rows[0]["stats"])
+	assert.Equal(t, "warning", rows[2]["status"])
+	assert.Contains(t, rows[2]["error"], "2 files unreadable")
+	assert.Equal(t, "failed", rows[3]["status"])
+	assert.Equal(t, "connection refused", rows[3]["error"])
+	assert.Equal(t, "running", rows[4]["status"])
+	assert.NotContains(t, rows[4], "error")
+	assert.Equal(t, "canceled", rows[5]["status"])
+}
+
+func TestCollector_ObserveModel(t *testing.T) {
+	prog := &progress.Manual{N: 1, Total: 4}
+	tsk, _ := taskprogress.NewWithHandle(
+		taskprogress.WithProgress(prog),
+		taskprogress.WithStager(&progress.Stage{Current: "step 1"}),
+	)
+	tsk.TitleOptions = taskprogress.Title{
+		Default: "Build image",
+		Running: "Building image",
+		Success: "Built image",
+	}
+
+	c := New()
+	c.Observe(tsk)
+
+	refresh := func(m taskprogress.Model) taskprogress.Model {
+		next, _ := m.Update(taskprogress.TickMsg{Time: time.Now(), ID: m.ID(), Sequence: m.Sequence()})
+		return next.(taskprogress.Model)
+	}
+
+	tsk = refresh(tsk)
+	rows := c.Rows()
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Building image", rows[0].Title)
+	assert.Equal(t, taskprogress.StateRunning, rows[0].State)
+	assert.Equal(t, int64(1), rows[0].Current)
+	assert.Equal(t, int64(4), rows[0].Size)
+	assert.Equal(t, []string{"step 1"}, rows[0].Stats)
+
+	prog.N = 4
+	prog.SetCompleted()
+	refresh(tsk)
+
+	rows = c.Rows()
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Built image", rows[0].Title)
+	assert.Equal(t, taskprogress.StateSuccess, rows[0].State)
+	assert.GreaterOrEqual(t, rows[0].Duration, time.Duration(0))
+	assert.NoError(t, rows[0].Err)
+}
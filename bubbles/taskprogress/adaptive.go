@@ -0,0 +1,61 @@
+package taskprogress
+
+import (
+	"time"
+
+	"github.com/wagoodman/go-progress"
+)
+
+// observation is the subset of task state used to determine if anything has changed between polls.
+type observation struct {
+	current int64
+	size    int64
+	err     string
+	stage   string
+}
+
+func observe(p progress.Progress, stage string) observation {
+	o := observation{
+		current: p.Current(),
+		size:    p.Size(),
+		stage:   stage,
+	}
+	if err := p.Error(); err != nil {
+		o.err = err.Error()
+	}
+	return o
+}
+
+func (m Model) isAdaptive() bool {
+	return m.refreshMin > 0
+}
+
+// tickInterval is how long to wait before polling the progressor again.
+func (m Model) tickInterval() time.Duration {
+	if m.isAdaptive() && m.refreshInterval > 0 {
+		return m.refreshInterval
+	}
+	return m.UpdateDuration
+}
+
+// stopPolling indicates that no further polls are needed: completed tasks are not polled, although stage changes are
+// still picked up whenever the model is updated.
+func (m Model) stopPolling() bool {
+	return m.completed
+}
+
+// adapt adjusts the refresh interval based on whether anything changed since the last poll: the interval is reset to
+// the minimum when values change and doubles (up to the maximum) while values stay the same.
+func (m *Model) adapt(o observation) {
+	if !m.isAdaptive() {
+		return
+	}
+
+	switch {
+	case m.lastObservation == nil || *m.lastObservation != o:
+		m.refreshInterval = m.refreshMin
+	default:
+		m.refreshInterval = min(m.refreshInterval*2, m.refreshMax)
+	}
+	m.lastObservation = &o
+}
@@ -0,0 +1,141 @@
+package taskprogress
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/wagoodman/go-progress"
+)
+
+func TestModel_AdaptiveRefresh(t *testing.T) {
+	prog := &progress.Manual{N: 1, Total: 100}
+	stage := &progress.Stage{Current: "working"}
+	tsk := New(&sync.WaitGroup{},
+		WithStagedProgressable(&struct {
+			progress.Stager
+			progress.Progressable
+		}{
+			Stager:       stage,
+			Progressable: prog,
+		}),
+		WithAdaptiveRefresh(100*time.Millisecond, time.Second),
+	)
+
+	tsk = tick(tsk)
+	assert.Equal(t, 100*time.Millisecond, tsk.tickInterval())
+
+	// back off while nothing changes...
+	var intervals []time.Duration
+	for i := 0; i < 5; i++ {
+		tsk = tick(tsk)
+		intervals = append(intervals, tsk.tickInterval())
+	}
+	assert.Equal(t, []time.Duration{
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}, intervals)
+
+	// ...and speed up as soon as something changes
+	prog.N = 50
+	tsk = tick(tsk)
+	assert.Equal(t, 100*time.Millisecond, tsk.tickInterval())
+
+	tsk = tick(tsk)
+	stage.Current = "still working"
+	tsk = tick(tsk)
+	assert.Equal(t, 100*time.Millisecond, tsk.tickInterval())
+
+	// stop polling after completion...
+	prog.SetCompleted()
+	prog.N = 100
+	tsk = tick(tsk)
+	assert.True(t, tsk.completed)
+	assert.Nil(t, tsk.queueNextTick(tsk.id, tsk.sequence))
+
+	// ...while still reacting to stage changes and window resizes
+	stage.Current = "done!"
+	tsk = update(tsk, tea.WindowSizeMsg{Width: 80, Height: 20})
+	assert.Equal(t, 80, tsk.WindowSize.Width)
+	assert.Equal(t, []string{"done!"}, tsk.hints)
+}
+
+func TestModel_StopsPollingWhenCompleted(t *testing.T) {
+	woops := errors.New("woops")
+
+	tests := []struct {
+		name     string
+		prog     *progress.Manual
+		retrier  Retrier
+		wantPoll bool
+	}{
+		{
+			name:     "running",
+			prog:     &progress.Manual{N: 1, Total: 10},
+			wantPoll: true,
+		},
+		{
+			name: "succeeded",
+			prog: &progress.Manual{N: 10, Total: 10, Err: progress.ErrCompleted},
+		},
+		{
+			name: "failed",
+			prog: &progress.Manual{N: 10, Total: 10, Err: woops},
+		},
+		{
+			name:     "failed but may be retried",
+			prog:     &progress.Manual{N: 10, Total: 10, Err: woops},
+			retrier:  attemptFunc(Attempt{Number: 1, Max: 3}),
+			wantPoll: true,
+		},
+		{
+			name:    "failed with no retries left",
+			prog:    &progress.Manual{N: 10, Total: 10, Err: woops},
+			retrier: attemptFunc(Attempt{Number: 3, Max: 3}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithProgress(tt.prog)}
+			if tt.retrier != nil {
+				opts = append(opts, WithRetrier(tt.retrier))
+			}
+			tsk := New(&sync.WaitGroup{}, opts...)
+
+			tsk = tick(tsk)
+
+			assert.Equal(t, tt.wantPoll, tsk.queueNextTick(tsk.id, tsk.sequence) != nil)
+		})
+	}
+}
+
+func TestModel_AdaptiveRefreshWithScheduler(t *testing.T) {
+	s := NewScheduler(10 * time.Millisecond)
+	prog := &progress.Manual{N: 1, Total: 100}
+	tsk := New(&sync.WaitGroup{}, WithProgress(prog), WithScheduler(s), WithAdaptiveRefresh(10*time.Millisecond, 40*time.Millisecond))
+	tsk.Init()
+
+	start := time.Now()
+	tickAt := func(seq int, offset time.Duration) {
+		tsk = update(tsk, SchedulerTickMsg{Time: start.Add(offset), ID: s.ID(), Sequence: seq})
+	}
+
+	tickAt(0, 0)
+	tickAt(1, 10*time.Millisecond)
+	assert.Equal(t, 20*time.Millisecond, tsk.tickInterval())
+
+	// polls are skipped until the backoff has elapsed
+	prog.N = 2
+	tickAt(2, 20*time.Millisecond)
+	assert.Equal(t, int64(1), tsk.lastObservation.current)
+
+	tickAt(3, 30*time.Millisecond)
+	assert.Equal(t, int64(2), tsk.lastObservation.current)
+	assert.Equal(t, 10*time.Millisecond, tsk.tickInterval())
+}
@@ -0,0 +1,196 @@
+package taskprogress
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/wagoodman/go-progress"
+)
+
+var (
+	_ progress.Progressable = (*Aggregate)(nil)
+	_ Starter               = (*Aggregate)(nil)
+)
+
+// Weighting controls how much each child contributes to the progress of an Aggregate.
+type Weighting int
+
+const (
+	// WeightBySize makes each child contribute in proportion to its size (e.g. bytes or file counts). Children that
+	// do not yet know their size do not contribute until they complete, although they keep the aggregate from
+	// completing in the meantime.
+	WeightBySize Weighting = iota
+
+	// WeightEqually makes each child contribute the same amount, regardless of its size.
+	WeightEqually
+)
+
+// equalWeight is the size each child is normalized to when using WeightEqually.
+const equalWeight = 100
+
+// Aggregate is a progress.Progressable that combines the progress of several child progressables into a single
+// parent progress, suitable for use with WithProgress. The aggregate is complete when all children are complete,
+// and is considered failed as soon as any child reports an error. Warnings from children (see CompletedWithWarnings)
+// are collected and reported by the aggregate once it completes.
+type Aggregate struct {
+	lock      *sync.RWMutex
+	children  []progress.Progressable
+	weighting Weighting
+}
+
+// NewAggregate returns an Aggregate over the given children.
+func NewAggregate(weighting Weighting, children ...progress.Progressable) *Aggregate {
+	return &Aggregate{
+		lock:      &sync.RWMutex{},
+		children:  children,
+		weighting: weighting,
+	}
+}
+
+// Add registers more children with the aggregate. This is safe to call while the aggregate is being observed.
+func (a *Aggregate) Add(children ...progress.Progressable) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.children = append(a.children, children...)
+}
+
+// Children returns the number of children registered with the aggregate.
+func (a *Aggregate) Children() int {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return len(a.children)
+}
+
+func (a *Aggregate) Current() int64 {
+	current, _, _ := a.snapshot()
+	return current
+}
+
+func (a *Aggregate) Size() int64 {
+	_, size, _ := a.snapshot()
+	return size
+}
+
+func (a *Aggregate) Error() error {
+	_, _, err := a.snapshot()
+	return err
+}
+
+// Started indicates that any of the children have started work (see Starter).
+func (a *Aggregate) Started() bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	for _, child := range a.children {
+		if s, ok := child.(Starter); ok && s.Started() {
+			return true
+		}
+		if child.Current() > 0 || child.Error() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Aggregate) snapshot() (current int64, size int64, err error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	var (
+		errs      []error
+		warnings  []error
+		warned    bool
+		completed int
+		sized     int
+	)
+
+	for _, child := range a.children {
+		childCurrent, childSize, childErr := child.Current(), child.Size(), child.Error()
+
+		var w *WarningError
+		switch {
+		case errors.As(childErr, &w):
+			// warnings are not fatal, but must not be lost when the parent completes
+			warned = true
+			warnings = append(warnings, w.Warnings...)
+		case childErr != nil && !progress.IsErrCompleted(childErr):
+			errs = append(errs, childErr)
+		}
+
+		isComplete := progress.IsCompleted(child)
+		if isComplete {
+			completed++
+		}
+
+		switch a.weighting {
+		case WeightEqually:
+			sized++
+			size += equalWeight
+			switch {
+			case isComplete:
+				current += equalWeight
+			case childSize > 0:
+				current += int64(equalWeight * ratio(childCurrent, childSize))
+			}
+		default:
+			switch {
+			case childSize >= 0:
+				sized++
+				size += childSize
+				current += min(childCurrent, childSize)
+			case isComplete:
+				// the child never reported a size but is done, so we can account for the work it did
+				sized++
+				size += childCurrent
+				current += childCurrent
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		// the parent is failed as soon as any child fails. Report the work as fully accounted for so that the
+		// aggregate is considered complete by observers (the same convention as progress.Manual.SetCompleted).
+		if size <= 0 {
+			size = 1
+		}
+		return size, size, errors.Join(errs...)
+	}
+
+	if len(a.children) > 0 && completed == len(a.children) {
+		if size <= 0 {
+			size = current
+		}
+		if warned {
+			return current, size, CompletedWithWarnings(warnings...)
+		}
+		return current, size, progress.ErrCompleted
+	}
+
+	if completed < len(a.children) && current >= size {
+		// the accounted work is done, but children that do not know their size yet are still running: the aggregate
+		// must not be considered complete until they are
+		if size <= 0 {
+			return current, -1, nil
+		}
+		current = size - 1
+	}
+
+	if sized == 0 {
+		// nothing is known about the total amount of work yet
+		return current, -1, nil
+	}
+
+	return current, size, nil
+}
+
+func ratio(current, size int64) float64 {
+	if current <= 0 || size <= 0 {
+		return 0
+	}
+	if current >= size {
+		return 1
+	}
+	return float64(current) / float64(size)
+}
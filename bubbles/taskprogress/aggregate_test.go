@@ -0,0 +1,166 @@
+package taskprogress
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wagoodman/go-progress"
+)
+
+func TestAggregate(t *testing.T) {
+	woops := errors.New("woops")
+	skipped := errors.New("skipped 2 files")
+
+	tests := []struct {
+		name         string
+		weighting    Weighting
+		children     []progress.Progressable
+		wantCurrent  int64
+		wantSize     int64
+		wantComplete bool
+		wantErr      error
+		wantWarning  bool
+	}{
+		{
+			name:      "no children",
+			weighting: WeightBySize,
+			wantSize:  -1,
+		},
+		{
+			name:      "weighted by size",
+			weighting: WeightBySize,
+			children: []progress.Progressable{
+				&progress.Manual{N: 10, Total: 100},
+				&progress.Manual{N: 5, Total: 10},
+			},
+			wantCurrent: 15,
+			wantSize:    110,
+		},
+		{
+			name:      "weighted by size ignores unsized children until complete",
+			weighting: WeightBySize,
+			children: []progress.Progressable{
+				&progress.Manual{N: 10, Total: 100},
+				&progress.Manual{N: 5, Total: -1},
+				&progress.Manual{N: 7, Total: -1, Err: progress.ErrCompleted},
+			},
+			wantCurrent: 17,
+			wantSize:    107,
+		},
+		{
+			name:      "weighted by size is not complete while unsized children are running",
+			weighting: WeightBySize,
+			children: []progress.Progressable{
+				&progress.Manual{N: 100, Total: 100, Err: progress.ErrCompleted},
+				&progress.Manual{N: 3, Total: -1},
+			},
+			wantCurrent: 99,
+			wantSize:    100,
+		},
+		{
+			name:      "weighted by size with empty children is not complete while unsized children are running",
+			weighting: WeightBySize,
+			children: []progress.Progressable{
+				&progress.Manual{N: 0, Total: 0, Err: progress.ErrCompleted},
+				&progress.Manual{N: 0, Total: -1},
+			},
+			wantSize: -1,
+		},
+		{
+			name:      "weighted equally",
+			weighting: WeightEqually,
+			children: []progress.Progressable{
+				&progress.Manual{N: 50, Total: 100},
+				&progress.Manual{N: 10, Total: 10},
+				&progress.Manual{N: 5, Total: -1},
+			},
+			wantCurrent: 150,
+			wantSize:    300,
+		},
+		{
+			name:      "all children complete",
+			weighting: WeightEqually,
+			children: []progress.Progressable{
+				&progress.Manual{N: 100, Total: 100},
+				&progress.Manual{N: 3, Total: -1, Err: progress.ErrCompleted},
+			},
+			wantCurrent:  200,
+			wantSize:     200,
+			wantComplete: true,
+			wantErr:      progress.ErrCompleted,
+		},
+		{
+			name:      "any failed child fails the parent",
+			weighting: WeightBySize,
+			children: []progress.Progressable{
+				&progress.Manual{N: 10, Total: 100},
+				&progress.Manual{N: 1, Total: 10, Err: woops},
+			},
+			wantCurrent:  110,
+			wantSize:     110,
+			wantComplete: true,
+			wantErr:      woops,
+		},
+		{
+			name:      "child warnings are reported once all children complete",
+			weighting: WeightEqually,
+			children: []progress.Progressable{
+				&progress.Manual{N: 10, Total: 10, Err: progress.ErrCompleted},
+				&progress.Manual{N: 10, Total: 10, Err: CompletedWithWarnings(skipped)},
+			},
+			wantCurrent:  200,
+			wantSize:     200,
+			wantComplete: true,
+			wantErr:      skipped,
+			wantWarning:  true,
+		},
+		{
+			name:      "child warnings while other children are running",
+			weighting: WeightEqually,
+			children: []progress.Progressable{
+				&progress.Manual{N: 5, Total: 10},
+				&progress.Manual{N: 10, Total: 10, Err: CompletedWithWarnings(skipped)},
+			},
+			wantCurrent: 150,
+			wantSize:    200,
+		},
+		{
+			name:      "a failed child takes precedence over warnings",
+			weighting: WeightEqually,
+			children: []progress.Progressable{
+				&progress.Manual{N: 1, Total: 10, Err: woops},
+				&progress.Manual{N: 10, Total: 10, Err: CompletedWithWarnings(skipped)},
+			},
+			wantCurrent:  200,
+			wantSize:     200,
+			wantComplete: true,
+			wantErr:      woops,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject := NewAggregate(tt.weighting, tt.children...)
+
+			assert.Equal(t, tt.wantCurrent, subject.Current())
+			assert.Equal(t, tt.wantSize, subject.Size())
+			assert.Equal(t, tt.wantComplete, progress.IsCompleted(subject))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, subject.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, subject.Error())
+			}
+			assert.Equal(t, tt.wantWarning, IsWarning(subject.Error()))
+		})
+	}
+}
+
+func TestAggregate_Add(t *testing.T) {
+	subject := NewAggregate(WeightBySize)
+	subject.Add(&progress.Manual{N: 1, Total: 2})
+	subject.Add(&progress.Manual{N: 2, Total: 2}, &progress.Manual{N: 0, Total: 4})
+
+	assert.Equal(t, 3, subject.Children())
+	assert.Equal(t, int64(3), subject.Current())
+	assert.Equal(t, int64(8), subject.Size())
+}
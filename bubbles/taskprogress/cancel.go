@@ -0,0 +1,63 @@
+package taskprogress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// FocusMsg moves focus to the task with the given ID (see Model.ID). Since every task receives the message, all
+// other tasks lose focus; an ID of zero blurs all tasks.
+type FocusMsg struct {
+	ID int
+}
+
+// Focus allows the task to react to its keybindings (e.g. CancelKey).
+func (m *Model) Focus() {
+	m.focused = true
+}
+
+// Blur stops the task from reacting to its keybindings.
+func (m *Model) Blur() {
+	m.focused = false
+}
+
+// Focused indicates that the task reacts to its keybindings.
+func (m Model) Focused() bool {
+	return m.focused
+}
+
+// Cancel invokes the cancel function the task was configured with (see WithCancel). This is a no-op if the task is
+// not cancelable or has already finished.
+func (m *Model) Cancel() {
+	if !m.cancelable() {
+		return
+	}
+	m.cancelFunc()
+	m.cancelRequested = true
+}
+
+func (m Model) cancelable() bool {
+	return m.cancelFunc != nil && !m.completed && !m.state.IsTerminal()
+}
+
+// canceled indicates that the work behind the task has been canceled. Without a context to observe, only a
+// cancellation requested through this model is known about.
+func (m Model) canceled() bool {
+	if m.cancelCtx == nil {
+		return m.cancelRequested
+	}
+	return errors.Is(m.cancelCtx.Err(), context.Canceled)
+}
+
+// cancelHint describes how to cancel the task, shown only while the task is focused.
+func (m Model) cancelHint() string {
+	if !m.focused || !m.cancelable() {
+		return ""
+	}
+	if m.cancelRequested {
+		return "canceling..."
+	}
+	help := m.CancelKey.Help()
+	return fmt.Sprintf("%s to %s", help.Key, help.Desc)
+}
@@ -0,0 +1,161 @@
+package taskprogress
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/bubbly/bubbles/internal/testutil"
+)
+
+var cancelKey = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+
+func TestModel_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	prog := &progress.Manual{N: 1, Total: 10}
+	tsk, handle := NewWithHandle(WithProgress(prog), WithCancel(ctx, cancel))
+	tsk = tick(tsk)
+
+	// keys are ignored while not focused
+	tsk = update(tsk, cancelKey)
+	require.NoError(t, ctx.Err())
+
+	tsk = update(tsk, FocusMsg{ID: tsk.ID()})
+	require.True(t, tsk.Focused())
+	assert.Equal(t, "x to cancel", tsk.cancelHint())
+
+	tsk = update(tsk, cancelKey)
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.Equal(t, "canceling...", tsk.cancelHint())
+
+	// the producer has not reported the cancellation, but the context has been canceled
+	tsk = tick(tsk)
+	assert.Equal(t, StateCanceled, tsk.State())
+	assert.Empty(t, tsk.cancelHint())
+	assert.True(t, tsk.completed)
+
+	select {
+	case <-handle.Done():
+	case <-time.After(time.Second):
+		t.Fatal("handle was not released")
+	}
+	assert.Equal(t, StateCanceled, handle.State())
+	assert.ErrorIs(t, handle.Err(), context.Canceled)
+}
+
+func TestModel_Cancel_ReportedByProducer(t *testing.T) {
+	prog := &progress.Manual{N: 1, Total: 10}
+	tsk := New(&sync.WaitGroup{}, WithProgress(prog))
+	tsk = tick(tsk)
+	require.Equal(t, StateRunning, tsk.State())
+
+	prog.Err = context.Canceled
+	tsk = tick(tsk)
+	assert.Equal(t, StateCanceled, tsk.State())
+	assert.True(t, tsk.completed)
+	assert.False(t, tsk.showErrorDetail())
+}
+
+func TestModel_Cancel_CausedByFailure(t *testing.T) {
+	// one child fails, and the remaining children are canceled as a result (as with errgroup)
+	agg := NewAggregate(WeightBySize,
+		&progress.Manual{N: 10, Total: 10, Err: errors.New("registry unauthorized")},
+		&progress.Manual{N: 3, Total: 10, Err: context.Canceled},
+	)
+	tsk, handle := NewWithHandle(WithProgress(agg))
+	tsk.ErrorDetail = ErrorDetailOnCompletion
+	tsk = tick(tsk)
+
+	assert.Equal(t, StateFailed, tsk.State())
+	assert.True(t, tsk.IsFailed())
+	assert.True(t, tsk.showErrorDetail())
+	assert.Equal(t, StateFailed, handle.State())
+	assert.ErrorContains(t, handle.Err(), "registry unauthorized")
+}
+
+func TestModel_Cancel_NotCancelable(t *testing.T) {
+	prog := &progress.Manual{N: 10, Total: 10}
+	called := false
+	tsk := New(&sync.WaitGroup{}, WithProgress(prog), WithCancel(nil, func() { called = true }))
+	tsk.Focus()
+	tsk = tick(tsk)
+
+	// finished tasks can no longer be canceled
+	tsk = update(tsk, cancelKey)
+	assert.False(t, called)
+	assert.Equal(t, StateSuccess, tsk.State())
+}
+
+func TestModel_FocusMsg(t *testing.T) {
+	a := New(&sync.WaitGroup{})
+	b := New(&sync.WaitGroup{})
+	a.Focus()
+
+	a = update(a, FocusMsg{ID: b.ID()})
+	b = update(b, FocusMsg{ID: b.ID()})
+	assert.False(t, a.Focused())
+	assert.True(t, b.Focused())
+
+	b = update(b, FocusMsg{})
+	assert.False(t, b.Focused())
+}
+
+func TestModel_View_Cancel(t *testing.T) {
+	tests := []struct {
+		name    string
+		taskGen func(testing.TB) Model
+	}{
+		{
+			name: "focused task shows how to cancel",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 40, 100
+				WithCancel(context.Background(), func() {})(&tsk)
+				tsk.Focus()
+				return tsk
+			},
+		},
+		{
+			name: "unfocused task does not",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 40, 100
+				WithCancel(context.Background(), func() {})(&tsk)
+				return tsk
+			},
+		},
+		{
+			name: "canceled",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 40, 100
+				prog.Err = context.Canceled
+				tsk.TitleOptions.Canceled = "Canceled work"
+				tsk.ErrorDetail = ErrorDetailAlways
+				return tsk
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsk := tt.taskGen(t)
+			got := testutil.RunModel(t, tsk, 1, TickMsg{
+				Time:     time.Now(),
+				Sequence: tsk.sequence,
+				ID:       tsk.id,
+			})
+			t.Log(got)
+			snaps.MatchSnapshot(t, got)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package taskprogress
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/anchore/bubbly"
+)
+
+var _ bubbly.VisibleModel = (*Composite)(nil)
+
+// Composite renders a parent task line with (optionally) the lines of its child tasks beneath it. The parent is
+// typically configured with an Aggregate over the same progressables that drive the children.
+type Composite struct {
+	Parent   Model
+	Children []Model
+
+	ShowChildren          bool
+	HideChildrenOnSuccess bool
+	ChildIndent           string
+}
+
+// NewComposite returns a composite model that shows the given parent and children.
+func NewComposite(parent Model, children ...Model) Composite {
+	return Composite{
+		Parent:                parent,
+		Children:              children,
+		ShowChildren:          true,
+		HideChildrenOnSuccess: true,
+		ChildIndent:           "   ",
+	}
+}
+
+// Init starts the update loop for the parent and all children.
+func (c Composite) Init() tea.Cmd {
+	cmds := []tea.Cmd{c.Parent.Init()}
+	for _, child := range c.Children {
+		cmds = append(cmds, child.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+// Update is the Tea update function.
+func (c Composite) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	parent, cmd := c.Parent.Update(msg)
+	c.Parent = parent.(Model)
+	cmds = append(cmds, cmd)
+
+	childMsg := msg
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		// children are rendered indented, so they have less room to work with
+		size.Width -= lipgloss.Width(c.ChildIndent)
+		childMsg = size
+	}
+
+	children := make([]Model, len(c.Children))
+	for i, child := range c.Children {
+		updated, cmd := child.Update(childMsg)
+		children[i] = updated.(Model)
+		cmds = append(cmds, cmd)
+	}
+	c.Children = children
+
+	return c, tea.Batch(cmds...)
+}
+
+func (c Composite) IsVisible() bool {
+	return c.Parent.IsVisible()
+}
+
+// View renders the parent line followed by any child lines.
+func (c Composite) View() string {
+	parent := c.Parent.View()
+	if !c.showChildren() {
+		return parent
+	}
+
+	lines := []string{parent}
+	for _, child := range c.Children {
+		if !child.IsVisible() {
+			continue
+		}
+		view := child.View()
+		if view == "" {
+			continue
+		}
+		for _, line := range strings.Split(view, "\n") {
+			lines = append(lines, c.ChildIndent+line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c Composite) showChildren() bool {
+	if !c.ShowChildren || len(c.Children) == 0 {
+		return false
+	}
+	if c.HideChildrenOnSuccess && c.Parent.completed && c.Parent.state == StateSuccess {
+		return false
+	}
+	return c.Parent.IsVisible()
+}
@@ -0,0 +1,89 @@
+package taskprogress
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/bubbly/bubbles/glyphs"
+	"github.com/anchore/bubbly/bubbles/internal/testutil"
+)
+
+func compositeSubject(children ...*progress.Manual) Composite {
+	wg := &sync.WaitGroup{}
+	newTask := func(title string, prog progress.Progressable) Model {
+		tsk := New(wg, WithProgress(prog), WithGlyphs(glyphs.Unicode), WithNoStyle())
+		tsk.HideProgressOnSuccess = true
+		tsk.TitleOptions = Title{Default: title}
+		tsk.WindowSize = tea.WindowSizeMsg{Width: 100, Height: 60}
+		return tsk
+	}
+
+	agg := NewAggregate(WeightEqually)
+	var childTasks []Model
+	for i, child := range children {
+		agg.Add(child)
+		childTasks = append(childTasks, newTask("child "+string(rune('a'+i)), child))
+	}
+
+	return NewComposite(newTask("parent", agg), childTasks...)
+}
+
+func TestComposite_View(t *testing.T) {
+	tests := []struct {
+		name    string
+		taskGen func() Composite
+	}{
+		{
+			name: "running shows children",
+			taskGen: func() Composite {
+				return compositeSubject(
+					&progress.Manual{N: 10, Total: 10},
+					&progress.Manual{N: 5, Total: 10},
+				)
+			},
+		},
+		{
+			name: "children can be hidden",
+			taskGen: func() Composite {
+				c := compositeSubject(
+					&progress.Manual{N: 10, Total: 10},
+					&progress.Manual{N: 5, Total: 10},
+				)
+				c.ShowChildren = false
+				return c
+			},
+		},
+		{
+			name: "success collapses children",
+			taskGen: func() Composite {
+				return compositeSubject(
+					&progress.Manual{N: 10, Total: 10},
+					&progress.Manual{N: 10, Total: 10},
+				)
+			},
+		},
+		{
+			name: "failed child fails parent",
+			taskGen: func() Composite {
+				return compositeSubject(
+					&progress.Manual{N: 10, Total: 10},
+					&progress.Manual{N: 10, Total: 10, Err: errors.New("woops")},
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a tick without an ID is handled by the parent and every child
+			got := testutil.RunModel(t, tt.taskGen(), 1, TickMsg{Time: time.Now()})
+			t.Log(got)
+			snaps.MatchSnapshot(t, got)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package taskprogress
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ErrorDetail controls when the text of a task error is rendered beneath the task line.
+type ErrorDetail int
+
+const (
+	// ErrorDetailHidden never shows error text, only the failed icon.
+	ErrorDetailHidden ErrorDetail = iota
+
+	// ErrorDetailAlways shows error text as soon as the task reports an error, even if it is still running.
+	ErrorDetailAlways
+
+	// ErrorDetailOnCompletion shows error text only once the task has failed.
+	ErrorDetailOnCompletion
+
+	// ErrorDetailToggle shows error text for failed tasks only after the ErrorDetailKey has been pressed.
+	ErrorDetailToggle
+)
+
+const (
+	errorDetailIndent = "   "
+	errorDetailBullet = "• "
+)
+
+func (m Model) showErrorDetail() bool {
+	if m.err == nil || m.state == StateCanceled {
+		// canceled tasks are not failures, so there is nothing to detail
+		return false
+	}
+	switch m.ErrorDetail {
+	case ErrorDetailAlways:
+		return true
+	case ErrorDetailOnCompletion:
+		return m.completed
+	case ErrorDetailToggle:
+		return m.completed && m.errorDetailToggled
+	}
+	return false
+}
+
+// renderErrorDetail renders the error messages indented beneath the task line (aligned with the title), wrapped to
+// the window width. Errors that are composed of several errors are rendered as a list.
+func (m Model) renderErrorDetail() string {
+	msgs := append(m.attemptMessages(), errorMessages(m.err)...)
+	if len(msgs) == 0 {
+		return ""
+	}
+
+	prefix, hanging := errorDetailIndent, errorDetailIndent
+	if len(msgs) > 1 {
+		prefix += errorDetailBullet
+		hanging += strings.Repeat(" ", lipgloss.Width(errorDetailBullet))
+	}
+
+	width := m.WindowSize.Width - lipgloss.Width(prefix)
+
+	var lines []string
+	for _, msg := range msgs {
+		if width > 0 {
+			msg = ansi.Wrap(msg, width, "")
+		}
+		for i, line := range strings.Split(msg, "\n") {
+			lead := hanging
+			if i == 0 {
+				lead = prefix
+			}
+			lines = append(lines, lead+m.ErrorDetailStyle.Render(line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// errorMessages flattens the given error into the messages of all of its constituent errors. Errors joined with
+// errors.Join (or any error that exposes several wrapped errors) are expanded.
+func errorMessages(err error) []string {
+	if err == nil {
+		return nil
+	}
+
+	var errs []error
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		errs = e.Unwrap()
+	case interface{ WrappedErrors() []error }:
+		errs = e.WrappedErrors()
+	default:
+		return []string{strings.TrimSpace(err.Error())}
+	}
+
+	var msgs []string
+	for _, e := range errs {
+		msgs = append(msgs, errorMessages(e)...)
+	}
+	return msgs
+}
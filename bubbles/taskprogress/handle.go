@@ -0,0 +1,127 @@
+package taskprogress
+
+import (
+	"sync"
+	"time"
+)
+
+// Handle reports on the lifecycle of a task independently of how (or whether) the task is rendered. It follows the
+// same conventions as context.Context: Done is closed once the task reaches a terminal state, after which Err
+// describes how the task finished.
+type Handle struct {
+	once *sync.Once
+	done chan struct{}
+
+	lock   *sync.RWMutex
+	state  State
+	err    error
+	status Status
+	onDone []func()
+}
+
+// Status is a point-in-time description of a task, as of the last time its model was refreshed.
+type Status struct {
+	Title      string
+	State      State
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Current    int64
+	Size       int64
+	Hints      []string
+	// Err is the error the task failed with, or the warnings it completed with.
+	Err error
+}
+
+// Duration is how long the task has been running (or ran for, once finished).
+func (s Status) Duration() time.Duration {
+	if s.StartedAt.IsZero() {
+		return 0
+	}
+	if s.FinishedAt.IsZero() {
+		return time.Since(s.StartedAt)
+	}
+	return s.FinishedAt.Sub(s.StartedAt)
+}
+
+func newHandle() *Handle {
+	return &Handle{
+		once:  &sync.Once{},
+		done:  make(chan struct{}),
+		lock:  &sync.RWMutex{},
+		state: StateRunning,
+		status: Status{
+			State: StateRunning,
+		},
+	}
+}
+
+// Done returns a channel that is closed when the task reaches a terminal state.
+func (h *Handle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Err returns the error the task failed with (context.Canceled if the task was canceled). This is nil while the
+// task is running and when it completed successfully (with or without warnings).
+func (h *Handle) Err() error {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.err
+}
+
+// State returns the terminal state of the task, or StateRunning if the task has not finished.
+func (h *Handle) State() State {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.state
+}
+
+// Status returns the most recent status of the task.
+func (h *Handle) Status() Status {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	s := h.status
+	s.Hints = append([]string(nil), s.Hints...)
+	return s
+}
+
+// report records the most recent status of the task.
+func (h *Handle) report(s Status) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.status = s
+}
+
+// afterDone calls the given function once the task has reached a terminal state (immediately if it already has).
+func (h *Handle) afterDone(fn func()) {
+	h.lock.Lock()
+	select {
+	case <-h.done:
+		h.lock.Unlock()
+		fn()
+		return
+	default:
+	}
+	h.onDone = append(h.onDone, fn)
+	h.lock.Unlock()
+}
+
+// finish records the terminal state of the task. Only the first call has any effect.
+func (h *Handle) finish(state State, err error) {
+	h.once.Do(func() {
+		h.lock.Lock()
+		h.state = state
+		h.err = err
+		callbacks := h.onDone
+		h.onDone = nil
+		close(h.done)
+		h.lock.Unlock()
+
+		for _, fn := range callbacks {
+			fn()
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package taskprogress
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-progress"
+)
+
+func isDone(h *Handle) bool {
+	select {
+	case <-h.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestHandle(t *testing.T) {
+	woops := errors.New("woops")
+
+	tests := []struct {
+		name      string
+		prog      *progress.Manual
+		wantDone  bool
+		wantState State
+		wantErr   error
+	}{
+		{
+			name:      "running",
+			prog:      &progress.Manual{N: 1, Total: 10},
+			wantState: StateRunning,
+		},
+		{
+			name:      "success",
+			prog:      &progress.Manual{N: 10, Total: 10},
+			wantDone:  true,
+			wantState: StateSuccess,
+		},
+		{
+			name:      "completed with warnings",
+			prog:      &progress.Manual{N: 1, Total: 10, Err: CompletedWithWarnings(woops)},
+			wantDone:  true,
+			wantState: StateWarning,
+		},
+		{
+			name:      "failed",
+			prog:      &progress.Manual{N: 10, Total: 10, Err: woops},
+			wantDone:  true,
+			wantState: StateFailed,
+			wantErr:   woops,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsk, handle := NewWithHandle(WithProgress(tt.prog))
+			require.Same(t, handle, tsk.Handle())
+
+			// note: the model is never rendered
+			tick(tsk)
+
+			assert.Equal(t, tt.wantDone, isDone(handle))
+			assert.Equal(t, tt.wantState, handle.State())
+			assert.Equal(t, tt.wantErr, handle.Err())
+		})
+	}
+}
+
+func TestHandle_OnlyFinishesOnce(t *testing.T) {
+	prog := &progress.Manual{N: 10, Total: 10}
+	tsk, handle := NewWithHandle(WithProgress(prog))
+
+	tick(tsk)
+	require.True(t, isDone(handle))
+
+	prog.Err = errors.New("too late")
+	tick(tsk)
+
+	assert.Equal(t, StateSuccess, handle.State())
+	assert.NoError(t, handle.Err())
+}
+
+func TestNew_WaitGroupAdapter(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	prog := &progress.Manual{N: 1, Total: 10}
+	tsk := New(wg, WithProgress(prog))
+
+	released := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(released)
+	}()
+
+	tick(tsk)
+	select {
+	case <-released:
+		t.Fatal("wait group released before the task completed")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	prog.N = 10
+	tick(tsk)
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("wait group was not released")
+	}
+}
@@ -0,0 +1,189 @@
+package taskprogress
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	segmentGap = "  "
+	ellipsis   = "…"
+
+	// minTruncatedWidth is the narrowest a truncatable segment will be shrunk to before it is dropped
+	minTruncatedWidth = 4
+
+	// minBarWidth is the narrowest the progress bar will be shrunk to before it is dropped
+	minBarWidth = 5
+)
+
+// segment is a single part of a task line (e.g. the title or the progress bar). Segments are measured in terminal
+// cells (not bytes) and are shrunk or dropped in priority order when the line does not fit the window.
+type segment struct {
+	// render draws the segment to exactly the given width
+	render func(width int) string
+
+	priority int  // higher priority segments are shrunk and dropped last
+	natural  int  // the preferred width of the segment
+	compact  int  // the width of the segment without any padding
+	min      int  // the narrowest the segment can be shrunk to, below which it is dropped
+	required bool // the segment is shrunk but never dropped
+	fill     bool // the segment expands to use any remaining width
+	gap      bool // a gap is rendered after the segment
+}
+
+// layoutLine renders the given segments into a single line no wider than the given width (when the width is known).
+// When there is not enough room, padding is removed first, then segments are truncated and dropped from the lowest
+// priority up.
+func layoutLine(width int, segments []segment) string {
+	widths := make([]int, len(segments))
+	dropped := make([]bool, len(segments))
+	for i, s := range segments {
+		widths[i] = s.natural
+	}
+
+	if width > 0 {
+		fitSegments(width, segments, widths, dropped)
+
+		// any leftover room is given to the fill segment
+		if spare := width - lineWidth(segments, widths, dropped); spare > 0 {
+			for i, s := range segments {
+				if s.fill && !dropped[i] {
+					widths[i] += spare
+					break
+				}
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for i, s := range segments {
+		if dropped[i] {
+			continue
+		}
+		sb.WriteString(s.render(widths[i]))
+		if s.gap {
+			sb.WriteString(segmentGap)
+		}
+	}
+	return sb.String()
+}
+
+func fitSegments(width int, segments []segment, widths []int, dropped []bool) {
+	over := lineWidth(segments, widths, dropped) - width
+	if over <= 0 {
+		return
+	}
+
+	order := make([]int, len(segments))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return segments[a].priority - segments[b].priority
+	})
+
+	// first remove any padding...
+	for _, i := range order {
+		if over <= 0 {
+			return
+		}
+		take := min(widths[i]-segments[i].compact, over)
+		if take > 0 {
+			widths[i] -= take
+			over -= take
+		}
+	}
+
+	// ...then truncate or drop segments, least important first
+	for _, i := range order {
+		if over <= 0 {
+			return
+		}
+		s := segments[i]
+		slack := widths[i] - s.min
+		if slack >= over {
+			widths[i] -= over
+			return
+		}
+		if s.required {
+			widths[i] = s.min
+			over -= slack
+			continue
+		}
+		over -= widths[i] + gapWidth(s)
+		dropped[i] = true
+	}
+}
+
+func lineWidth(segments []segment, widths []int, dropped []bool) int {
+	var total int
+	for i, s := range segments {
+		if dropped[i] {
+			continue
+		}
+		total += widths[i] + gapWidth(s)
+	}
+	return total
+}
+
+func gapWidth(s segment) int {
+	if s.gap {
+		return lipgloss.Width(segmentGap)
+	}
+	return 0
+}
+
+// textSegment returns a segment for plain text that can be truncated with an ellipsis. If padTo is larger than the
+// text width then the text is padded (left-aligned) to that width.
+func textSegment(text string, style lipgloss.Style, padTo int, priority int) segment {
+	w := lipgloss.Width(text)
+	return segment{
+		render: func(width int) string {
+			return style.Render(padRight(truncate(text, width), width))
+		},
+		priority: priority,
+		natural:  max(w, padTo),
+		compact:  w,
+		min:      min(w, minTruncatedWidth),
+		gap:      true,
+	}
+}
+
+// fillSegment returns a segment for plain text that is right-aligned within whatever room is left on the line.
+func fillSegment(text string, style lipgloss.Style, priority int) segment {
+	w := lipgloss.Width(text)
+	return segment{
+		render: func(width int) string {
+			return style.Render(padLeft(truncate(text, width), width))
+		},
+		priority: priority,
+		natural:  w,
+		compact:  w,
+		min:      min(w, minTruncatedWidth),
+		fill:     true,
+	}
+}
+
+func truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	return ansi.Truncate(s, width, ellipsis)
+}
+
+func padRight(s string, width int) string {
+	if pad := width - lipgloss.Width(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+func padLeft(s string, width int) string {
+	if pad := width - lipgloss.Width(s); pad > 0 {
+		return strings.Repeat(" ", pad) + s
+	}
+	return s
+}
@@ -0,0 +1,81 @@
+package taskprogress
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayoutLine(t *testing.T) {
+	plain := lipgloss.NewStyle()
+	segments := func() []segment {
+		return []segment{
+			textSegment("title", plain, 10, titlePriority),
+			textSegment("[hint]", plain, 0, hintsPriority),
+			fillSegment("context", plain, contextPriority),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		width int
+		want  string
+	}{
+		{
+			name:  "unknown width renders naturally",
+			width: 0,
+			want:  "title       [hint]  context",
+		},
+		{
+			name:  "fill segment takes the remaining width",
+			width: 32,
+			want:  "title       [hint]       context",
+		},
+		{
+			name:  "padding is removed first",
+			width: 24,
+			want:  "title    [hint]  context",
+		},
+		{
+			name:  "lowest priority segment is truncated",
+			width: 20,
+			want:  "title  [hint]  cont…",
+		},
+		{
+			name:  "lowest priority segment is dropped",
+			width: 16,
+			want:  "title  [hint]  ",
+		},
+		{
+			name:  "required segments are truncated but never dropped",
+			width: 4,
+			want:  "tit…  ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segs := segments()
+			segs[0].required = true
+			assert.Equal(t, tt.want, layoutLine(tt.width, segs))
+		})
+	}
+}
+
+func TestModel_View_FitsWindow(t *testing.T) {
+	for width := 10; width <= 120; width++ {
+		prog, _, tsk := subject(t)
+		prog.N, prog.Total = 40, 100
+		tsk.TitleOptions.Running = "処理中のタスク with a title"
+		tsk.Hints = []string{"info++"}
+		tsk.WindowSize.Width = width
+
+		line := strings.Split(tick(tsk).View(), "\n")[0]
+
+		// the title is never dropped, so very narrow windows may still be exceeded by the icon and gaps
+		if width >= 16 {
+			assert.LessOrEqual(t, lipgloss.Width(line), width, "width=%d: %q", width, line)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package taskprogress
+
+import "context"
+
+// Completion controls what happens to a task in a live frame (see the frame package) once it finishes.
+type Completion int
+
+const (
+	// CompletionStayLive keeps the finished task in the live redraw area.
+	CompletionStayLive Completion = iota
+
+	// CompletionImprint prints the final view of the task to the scrollback (above the live area) and removes it from
+	// the live area. This applies regardless of how the task finished.
+	CompletionImprint
+
+	// CompletionHide hides a successful task from the live area, while it remains in the frame state. Tasks that did
+	// not succeed stay visible, since there is something the user should know about.
+	CompletionHide
+
+	// CompletionRemove removes a successful task from the frame entirely. Tasks that did not succeed stay live.
+	CompletionRemove
+)
+
+// IsHidden indicates that the task should not be rendered in the live frame.
+func (m Model) IsHidden() bool {
+	if !m.IsVisible() {
+		return true
+	}
+	return m.Completion == CompletionHide && m.IsSucceeded()
+}
+
+// IsAlive indicates that the task should be kept in the frame.
+func (m Model) IsAlive() bool {
+	return m.Completion != CompletionRemove || !m.IsSucceeded()
+}
+
+// ShouldImprint indicates that the task has finished and its final view should be printed to the scrollback.
+func (m Model) ShouldImprint() bool {
+	return m.Completion == CompletionImprint && m.completed && m.state.IsTerminal()
+}
+
+// IsCompleted indicates that the task will not make any further progress.
+func (m Model) IsCompleted() bool {
+	return m.completed
+}
+
+// IsFailed indicates that the task finished with an error.
+func (m Model) IsFailed() bool {
+	return m.completed && m.state == StateFailed
+}
+
+// IsSucceeded indicates that the task finished without any issues (it did not fail, was not canceled and had no
+// warnings).
+func (m Model) IsSucceeded() bool {
+	return m.completed && m.state == StateSuccess
+}
+
+// Release is called by frames when dropping the task. A task that is dropped before it finishes (e.g. removed from a
+// frame) no longer keeps its scheduler ticking (see WithScheduler), and its handle reports it as canceled so that
+// anything waiting on it (e.g. the wait group given to New) is released. The work itself is not canceled (see
+// WithCancel), and a released task should not be added to a frame again.
+func (m Model) Release() {
+	if m.scheduler != nil {
+		m.scheduler.unregister(m.id)
+	}
+	// only the first terminal state is recorded, so tasks that already finished keep their state
+	m.handle.finish(StateCanceled, context.Canceled)
+}
+
+// IsHidden delegates to the parent task.
+func (c Composite) IsHidden() bool {
+	return c.Parent.IsHidden()
+}
+
+// IsAlive delegates to the parent task.
+func (c Composite) IsAlive() bool {
+	return c.Parent.IsAlive()
+}
+
+// ShouldImprint delegates to the parent task, imprinting the parent and children together.
+func (c Composite) ShouldImprint() bool {
+	return c.Parent.ShouldImprint()
+}
+
+// IsCompleted delegates to the parent task.
+func (c Composite) IsCompleted() bool {
+	return c.Parent.IsCompleted()
+}
+
+// IsFailed delegates to the parent task.
+func (c Composite) IsFailed() bool {
+	return c.Parent.IsFailed()
+}
+
+// IsSucceeded delegates to the parent task.
+func (c Composite) IsSucceeded() bool {
+	return c.Parent.IsSucceeded()
+}
+
+// Release releases the parent and all children (see Model.Release).
+func (c Composite) Release() {
+	c.Parent.Release()
+	for _, child := range c.Children {
+		child.Release()
+	}
+}
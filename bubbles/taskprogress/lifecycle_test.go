@@ -0,0 +1,217 @@
+package taskprogress
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/bubbly/bubbles/frame"
+	"github.com/anchore/bubbly/bubbles/internal/testutil"
+)
+
+var (
+	_ frame.VisibleElement     = (*Model)(nil)
+	_ frame.TerminalElement    = (*Model)(nil)
+	_ frame.ImprintableElement = (*Model)(nil)
+	_ frame.CompletableElement = (*Model)(nil)
+	_ frame.VisibleElement     = (*Composite)(nil)
+	_ frame.TerminalElement    = (*Composite)(nil)
+	_ frame.ImprintableElement = (*Composite)(nil)
+	_ frame.CompletableElement = (*Composite)(nil)
+	_ frame.ReleasableElement  = (*Model)(nil)
+	_ frame.ReleasableElement  = (*Composite)(nil)
+)
+
+func TestModel_Lifecycle(t *testing.T) {
+	running := &progress.Manual{N: 1, Total: 10}
+	succeeded := &progress.Manual{N: 10, Total: 10}
+	failed := &progress.Manual{N: 10, Total: 10, Err: errors.New("woops")}
+
+	tests := []struct {
+		name        string
+		completion  Completion
+		prog        *progress.Manual
+		wantHidden  bool
+		wantAlive   bool
+		wantImprint bool
+		wantFailed  bool
+	}{
+		{name: "stay live: running", completion: CompletionStayLive, prog: running, wantAlive: true},
+		{name: "stay live: succeeded", completion: CompletionStayLive, prog: succeeded, wantAlive: true},
+		{name: "imprint: running", completion: CompletionImprint, prog: running, wantAlive: true},
+		{name: "imprint: succeeded", completion: CompletionImprint, prog: succeeded, wantAlive: true, wantImprint: true},
+		{name: "imprint: failed", completion: CompletionImprint, prog: failed, wantAlive: true, wantImprint: true, wantFailed: true},
+		{name: "hide: running", completion: CompletionHide, prog: running, wantAlive: true},
+		{name: "hide: succeeded", completion: CompletionHide, prog: succeeded, wantAlive: true, wantHidden: true},
+		{name: "hide: failed", completion: CompletionHide, prog: failed, wantAlive: true, wantFailed: true},
+		{name: "remove: running", completion: CompletionRemove, prog: running, wantAlive: true},
+		{name: "remove: succeeded", completion: CompletionRemove, prog: succeeded},
+		{name: "remove: failed", completion: CompletionRemove, prog: failed, wantAlive: true, wantFailed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsk := tick(New(&sync.WaitGroup{}, WithProgress(tt.prog), WithCompletion(tt.completion)))
+
+			assert.Equal(t, tt.wantHidden, tsk.IsHidden(), "hidden")
+			assert.Equal(t, tt.wantAlive, tsk.IsAlive(), "alive")
+			assert.Equal(t, tt.wantImprint, tsk.ShouldImprint(), "imprint")
+			assert.Equal(t, tt.prog != running, tsk.IsCompleted(), "completed")
+			assert.Equal(t, tt.wantFailed, tsk.IsFailed(), "failed")
+			assert.Equal(t, tt.prog == succeeded, tsk.IsSucceeded(), "succeeded")
+		})
+	}
+}
+
+func TestModel_IsSucceeded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		opts []Option
+		want bool
+	}{
+		{
+			name: "succeeded",
+			opts: []Option{WithProgress(&progress.Manual{N: 10, Total: 10})},
+			want: true,
+		},
+		{
+			name: "completed with warnings",
+			opts: []Option{WithProgress(&progress.Manual{N: 10, Total: 10, Err: CompletedWithWarnings(errors.New("skipped"))})},
+		},
+		{
+			name: "canceled",
+			opts: []Option{WithProgress(&progress.Manual{N: 1, Total: 10}), WithCancel(ctx, cancel)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsk := tick(New(&sync.WaitGroup{}, tt.opts...))
+
+			assert.True(t, tsk.IsCompleted())
+			assert.False(t, tsk.IsFailed())
+			assert.Equal(t, tt.want, tsk.IsSucceeded())
+		})
+	}
+}
+
+func TestModel_Lifecycle_HideOnSuccess(t *testing.T) {
+	tsk := New(&sync.WaitGroup{}, WithProgress(&progress.Manual{N: 10, Total: 10}))
+	tsk.HideOnSuccess = true
+	tsk = tick(tsk)
+
+	assert.True(t, tsk.IsHidden())
+}
+
+func TestModel_Lifecycle_ImprintedByFrame(t *testing.T) {
+	prog := &progress.Manual{N: 1, Total: 10}
+	tsk := New(&sync.WaitGroup{}, WithProgress(prog), WithCompletion(CompletionImprint), WithNoStyle())
+	tsk.TitleOptions = Title{Default: "Do work"}
+
+	f := frame.New()
+	f.ShowFooter(false)
+	f.AppendModel(tsk)
+
+	msg := TickMsg{Time: time.Now(), ID: tsk.ID()}
+	_, _ = f.Update(msg)
+	require.Contains(t, f.View(), "Do work")
+
+	prog.N = 10
+	_, _ = f.Update(msg)
+	require.Contains(t, f.View(), "Do work")
+
+	// the finished task is imprinted...
+	_, cmd := f.Update(msg)
+	assert.NotNil(t, cmd)
+
+	// ...and removed from the live frame on the next update
+	_, _ = f.Update(msg)
+	assert.Empty(t, f.View())
+}
+
+// tickIDs returns the IDs of the task ticks among the given messages.
+func tickIDs(msgs []tea.Msg) []int {
+	var ids []int
+	for _, msg := range msgs {
+		if tm, ok := msg.(TickMsg); ok {
+			ids = append(ids, tm.ID)
+		}
+	}
+	return ids
+}
+
+func TestModel_FrameInitStartsTicks(t *testing.T) {
+	before := New(&sync.WaitGroup{}, WithProgress(&progress.Manual{N: 1, Total: 10}))
+	after := New(&sync.WaitGroup{}, WithProgress(&progress.Manual{N: 1, Total: 10}))
+
+	f := frame.New()
+	f.AppendModel(before)
+	assert.Equal(t, []int{before.ID()}, tickIDs(testutil.Messages(f.Init())))
+
+	// tasks appended to a running frame start ticking on the next update
+	f.AppendModel(after)
+	_, cmd := f.Update(nil)
+	assert.Equal(t, []int{after.ID()}, tickIDs(testutil.Messages(cmd)))
+
+	// ...and the ticks keep the task updating from then on
+	_, cmd = f.Update(TickMsg{Time: time.Now(), ID: after.ID(), Sequence: after.sequence})
+	assert.Equal(t, []int{after.ID()}, tickIDs(testutil.Messages(cmd)))
+}
+
+func TestModel_GroupOnlyCollapsesOnSuccess(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ok := tick(New(&sync.WaitGroup{}, WithProgress(&progress.Manual{N: 10, Total: 10})))
+	warned := tick(New(&sync.WaitGroup{}, WithProgress(&progress.Manual{N: 10, Total: 10, Err: CompletedWithWarnings(errors.New("skipped"))})))
+	canceled := tick(New(&sync.WaitGroup{}, WithProgress(&progress.Manual{N: 1, Total: 10}), WithCancel(ctx, cancel)))
+
+	assert.True(t, frame.NewGroup("catalogers", ok, ok).Collapsed())
+	assert.False(t, frame.NewGroup("catalogers", ok, warned).Collapsed())
+	assert.False(t, frame.NewGroup("catalogers", ok, canceled).Collapsed())
+}
+
+func TestModel_ReleasedByFrame(t *testing.T) {
+	s := NewScheduler(time.Millisecond)
+	wg := &sync.WaitGroup{}
+	tsk := New(wg, WithProgress(&progress.Manual{N: 1, Total: 10}), WithScheduler(s))
+
+	f := frame.New()
+	require.NoError(t, f.AppendKeyed("task", tsk))
+	f.Init()
+	require.True(t, s.isRegistered(tsk.ID()))
+
+	// the task is still running, but it no longer keeps the scheduler ticking once it is removed
+	require.True(t, f.Remove("task"))
+	assert.False(t, s.isRegistered(tsk.ID()))
+
+	// nothing is left to finish the task, so it no longer holds up anyone waiting on it
+	wg.Wait()
+	assert.Equal(t, StateCanceled, tsk.Handle().State())
+	assert.ErrorIs(t, tsk.Handle().Err(), context.Canceled)
+
+	// ...and the scheduler starts ticking again for the next task
+	next := New(&sync.WaitGroup{}, WithProgress(&progress.Manual{N: 1, Total: 10}), WithScheduler(s))
+	f.AppendModel(next)
+	_, cmd := f.Update(nil)
+	assert.NotEmpty(t, testutil.Messages(cmd))
+	assert.True(t, s.isRegistered(next.ID()))
+}
+
+func TestModel_ReleaseKeepsTerminalState(t *testing.T) {
+	tsk, handle := NewWithHandle(WithProgress(&progress.Manual{N: 10, Total: 10, Err: progress.ErrCompleted}))
+	tsk = tick(tsk)
+
+	tsk.Release()
+
+	assert.Equal(t, StateSuccess, handle.State())
+	assert.NoError(t, handle.Err())
+}
@@ -0,0 +1,52 @@
+package taskprogress
+
+import (
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const logTailIndent = "   "
+
+// LogWriter returns a writer for the task's own output (e.g. the output of a build), the tail of which is shown
+// beneath the task line (see WithLogTail). It is safe to write to from any goroutine. If the model was not configured
+// with a log tail then all writes are discarded.
+func (m Model) LogWriter() io.Writer {
+	if m.logs == nil {
+		return io.Discard
+	}
+	return m.logs
+}
+
+// showLogTail indicates that the log tail should be shown: while the task is running, or when it did not succeed.
+func (m Model) showLogTail() bool {
+	return m.logs != nil && m.state != StateSuccess
+}
+
+func (m Model) renderLogTail() string {
+	lines := m.logs.Lines()
+	if len(lines) == 0 {
+		return ""
+	}
+	if over := len(lines) - m.logTailLines; m.logTailLines > 0 && over > 0 {
+		// the buffer also retains any unterminated line, which counts towards the lines shown
+		lines = lines[over:]
+	}
+
+	width := m.WindowSize.Width - lipgloss.Width(logTailIndent)
+
+	rendered := make([]string, 0, len(lines))
+	for _, line := range lines {
+		// only the last carriage-return delimited part of a line is what would be visible on a terminal
+		if idx := strings.LastIndex(strings.TrimRight(line, "\r"), "\r"); idx >= 0 {
+			line = line[idx+1:]
+		}
+		line = strings.TrimRight(strings.ReplaceAll(line, "\t", "    "), "\r ")
+		if width > 0 {
+			line = truncate(line, width)
+		}
+		rendered = append(rendered, logTailIndent+m.LogTailStyle.Render(line))
+	}
+	return strings.Join(rendered, "\n")
+}
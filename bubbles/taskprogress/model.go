@@ -1,13 +1,12 @@
 package taskprogress
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"strings"
 	"sync"
 	"time"
 
-	"github.com/acarl005/stripansi"
+	"github.com/charmbracelet/bubbles/key"
 	progressBubble "github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,11 +14,21 @@ import (
 	"github.com/wagoodman/go-progress"
 
 	"github.com/anchore/bubbly"
+	"github.com/anchore/bubbly/bubbles/glyphs"
+	"github.com/anchore/bubbly/bubbles/internal/linebuffer"
 )
 
+// segment priorities, from the first to be dropped to the last when space is short
 const (
-	checkMark = "✔"
-	xMark     = "✘"
+	contextPriority = iota
+	etaPriority
+	elapsedPriority
+	hintsPriority
+	sparklinePriority
+	percentPriority
+	barPriority
+	titlePriority
+	iconPriority
 )
 
 var _ bubbly.VisibleModel = (*Model)(nil)
@@ -28,67 +37,117 @@ type Model struct {
 	// ui components (view models)
 	Spinner     spinner.Model
 	ProgressBar progressBubble.Model
+	glyphs      glyphs.Set
 	title       string
 	hints       []string
 	context     []string
 
 	// enums for view model
-	TitleOptions Title
-	Hints        []string
-	Context      []string
+	TitleOptions    Title
+	Hints           []string
+	Context         []string
+	hintProvider    HintProvider
+	contextProvider ContextProvider
 
 	// state that drives view ui components
 	progress   *progress.Progress
 	progressor progress.Progressor
 	stager     progress.Stager
+	starter    Starter
+	retrier    Retrier
 	WindowSize tea.WindowSizeMsg
 	completed  bool
+	started    bool
+	startedAt  time.Time
+	finishedAt time.Time
+	stage      string
+	firstStage *string
+	state      State
 	err        error
 
 	UpdateDuration        time.Duration
+	refreshMin            time.Duration
+	refreshMax            time.Duration
+	refreshInterval       time.Duration
+	lastObservation       *observation
+	nextPoll              time.Time
 	HideProgressOnSuccess bool
 	HideStageOnSuccess    bool
 	HideOnSuccess         bool
-
-	TitleStyle lipgloss.Style
-	// TitlePendingStyle lipgloss.Style
-	HintStyle    lipgloss.Style
-	ContextStyle lipgloss.Style
-	SuccessStyle lipgloss.Style
-	FailedStyle  lipgloss.Style
-	TitleWidth   int
-	HintEndCaps  []string
-
-	id       int
-	sequence int
+	ShowPending           bool
+	ErrorDetail           ErrorDetail
+	Completion            Completion
+	ErrorDetailKey        key.Binding
+	CancelKey             key.Binding
+
+	TitleStyle        lipgloss.Style
+	TitlePendingStyle lipgloss.Style
+	PendingStyle      lipgloss.Style
+	HintStyle         lipgloss.Style
+	ContextStyle      lipgloss.Style
+	SuccessStyle      lipgloss.Style
+	WarningStyle      lipgloss.Style
+	FailedStyle       lipgloss.Style
+	CanceledStyle     lipgloss.Style
+	TitleWidth        int
+	HintEndCaps       []string
+	Layout            Layout
+
+	ErrorDetailStyle lipgloss.Style
+	LogTailStyle     lipgloss.Style
+	SparklineStyle   lipgloss.Style
+
+	errorDetailToggled bool
+
+	// cancellation of the task by the user
+	cancelCtx       context.Context
+	cancelFunc      context.CancelFunc
+	cancelRequested bool
+	focused         bool
+
+	id        int
+	sequence  int
+	scheduler *Scheduler
+
+	// the current and previous attempts at the work
+	attempt        Attempt
+	attemptHistory []Attempt
+
+	// recent throughput samples
+	sparkline sparkline
+
+	// the tail of the task's own output
+	logs         *linebuffer.Buffer
+	logTailLines int
 
 	// coordinate if there are any live components on the UI
-	done func()
+	handle *Handle
 }
 
-// New returns a model with default values.
+// New returns a model with default values. The wait group is released once the task reaches a terminal state (or is
+// dropped from a frame before it does, see Release).
 func New(wg *sync.WaitGroup, opts ...Option) Model {
 	wg.Add(1)
-	once := sync.Once{}
-	done := func() {
-		once.Do(wg.Done)
-	}
-	spin := spinner.New()
+	m, handle := NewWithHandle(opts...)
+	handle.afterDone(wg.Done)
+	return m
+}
 
-	// matches the same spinner as syft/grype
-	spin.Spinner = spinner.Spinner{
-		Frames: strings.Split("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏", ""),
-		FPS:    150 * time.Millisecond,
-	}
+// NewWithHandle returns a model with default values along with a handle that signals when the task reaches a
+// terminal state, regardless of whether (or how often) the model is rendered.
+func NewWithHandle(opts ...Option) (Model, *Handle) {
+	set := glyphs.Detect()
+
+	spin := spinner.New()
+	spin.Spinner = set.Spinner
 	spin.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("13")) // 13 = high intentity magenta (ANSI 16 bit color code)
 
 	prog := progressBubble.New(
 		progressBubble.WithoutPercentage(),
 		progressBubble.WithWidth(20),
 	)
-	// matches the same progress feel as syft/grype
-	prog.Full = '━'
-	prog.Empty = '━'
+	prog.Full = set.BarFull
+	prog.Empty = set.BarEmpty
 	// TODO: make responsive to light/dark themes
 	prog.EmptyColor = "#777777"
 	prog.FullColor = "#fcba03"
@@ -96,27 +155,48 @@ func New(wg *sync.WaitGroup, opts ...Option) Model {
 	m := Model{
 		Spinner:        spin,
 		ProgressBar:    prog,
+		glyphs:         set,
 		UpdateDuration: 250 * time.Millisecond,
 		id:             nextID(),
-		done:           done,
+		handle:         newHandle(),
+		state:          StateRunning,
 
 		TitleStyle: lipgloss.NewStyle().Bold(true),
-		//TitlePendingStyle: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.AdaptiveColor{
-		//	Light: "#555555",
-		//	Dark:  "#AAAAAA",
-		// }),
+		TitlePendingStyle: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.AdaptiveColor{
+			Light: "#555555",
+			Dark:  "#AAAAAA",
+		}),
+		PendingStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")),
 		ContextStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")),
 		HintStyle:    lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")),
 		SuccessStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("10")), // 10 = high intensity green (ANSI 16 bit color code)
+		WarningStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("11")), // 11 = high intensity yellow (ANSI 16 bit color code)
 		FailedStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("9")),  // 9 = high intensity red (ANSI 16 bit color code)
-		TitleWidth:   40,
-		HintEndCaps:  []string{"[", "]"},
+		CanceledStyle: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{
+			Light: "#555555",
+			Dark:  "#AAAAAA",
+		}),
+		TitleWidth:  40,
+		HintEndCaps: []string{"[", "]"},
+
+		ErrorDetailKey: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "toggle error details"),
+		),
+		CancelKey: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "cancel"),
+		),
+		ErrorDetailStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+		LogTailStyle:     lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")),
+		SparklineStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#fcba03")),
 	}
 
 	for _, opt := range opts {
 		opt(&m)
 	}
-	return m
+	m.handle.report(Status{Title: m.TitleOptions.Default, State: m.state})
+	return m, m.handle
 }
 
 func (m Model) hintCap(end bool) string {
@@ -132,6 +212,14 @@ func (m Model) hintCap(end bool) string {
 
 // Init is the command that effectively starts the continuous update loop.
 func (m Model) Init() tea.Cmd {
+	if m.scheduler != nil {
+		// the scheduler drives both the state updates and the spinner
+		return tea.Batch(
+			m.scheduler.register(m.id),
+			m.ProgressBar.Init(),
+		)
+	}
+
 	cmds := []tea.Cmd{
 		// this is the periodic update of state information
 		func() tea.Msg {
@@ -166,58 +254,54 @@ func (m Model) Sequence() int {
 	return m.sequence
 }
 
+// Handle returns the handle that signals when the task reaches a terminal state.
+func (m Model) Handle() *Handle {
+	return m.handle
+}
+
+// State returns the lifecycle state of the task as of the last update.
+func (m Model) State() State {
+	return m.state
+}
+
 // Update is the Tea update function.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.stopPolling() {
+		// completed tasks are no longer polled, but any message is a cheap opportunity to pick up stage changes
+		m.refreshStage()
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.WindowSize = msg
 		return m, nil
 
+	case tea.KeyMsg:
+		if m.ErrorDetail == ErrorDetailToggle && key.Matches(msg, m.ErrorDetailKey) {
+			m.errorDetailToggled = !m.errorDetailToggled
+		}
+		if m.focused && key.Matches(msg, m.CancelKey) {
+			m.Cancel()
+		}
+		return m, nil
+
+	case FocusMsg:
+		m.focused = msg.ID == m.id
+		return m, nil
+
 	case TickMsg:
-		tickCmd := m.handleTick(msg)
-		if tickCmd == nil {
+		if !m.handleTick(msg) {
 			// this tick is not meant for us
 			return m, nil
 		}
 
 		// this tick is meant for us... do an update!
-		var progCmd tea.Cmd
-
-		title := m.TitleOptions.Default
-		var prog *progress.Progress
-		if m.progressor != nil {
-			c := m.progressor.Progress()
-			title = m.TitleOptions.Title(c)
-			if c.Size() > 0 {
-				prog = &c
-				ratio := c.Ratio()
-				if m.ProgressBar.Percent() != ratio {
-					progCmd = m.ProgressBar.SetPercent(ratio)
-				}
-			}
-			m.completed = c.Complete()
-			if c.Error() != nil && !errors.Is(c.Error(), progress.ErrCompleted) {
-				m.err = c.Error()
-			}
-		}
-		m.title = title
-		m.progress = prog
-
-		if m.stager != nil {
-			stage := m.stager.Stage()
-			if stage != "" {
-				// TODO: how to deal with stages that have custom stats from the results of commands?
-				// TODO: list is awkward both in usage and display
-				m.hints = append([]string{stage}, m.Hints...)
-			} else {
-				m.hints = m.Hints
-			}
-		}
+		progCmd := m.refresh()
 
-		// TODO: rethink this
-		m.context = m.Context
+		return m, tea.Batch(m.queueNextTick(m.id, m.sequence), progCmd)
 
-		return m, tea.Batch(tickCmd, progCmd)
+	case SchedulerTickMsg:
+		return m.handleSchedulerTick(msg)
 
 	case progressBubble.FrameMsg:
 		progModel, progCmd := m.ProgressBar.Update(msg)
@@ -227,6 +311,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case spinner.TickMsg:
 		spinModel, spinCmd := m.Spinner.Update(msg)
 		m.Spinner = spinModel
+		if m.stopPolling() {
+			// the spinner is not shown for completed tasks
+			return m, nil
+		}
 		return m, spinCmd
 
 	default:
@@ -234,74 +322,224 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 
-func (m Model) IsVisible() bool {
-	isDoneAndHidden := m.completed && m.HideOnSuccess
-	if isDoneAndHidden {
-		// it might be that the consumer will not invoke View() again based on
-		// this response, in which case we need to ensure that the done() function
-		// in invoked to release resources
-		m.done()
+// refresh reads the latest state from the progressor and stager, returning any command needed to animate the
+// progress bar to the new state.
+func (m *Model) refresh() tea.Cmd {
+	var progCmd tea.Cmd
+
+	var (
+		prog    *progress.Progress
+		current progress.Progress
+		active  bool
+	)
+	m.state = StateRunning
+	// the error is always derived from the latest progress, since a retried task may go on to succeed
+	m.err = nil
+	if m.progressor != nil {
+		current = m.progressor.Progress()
+		if current.Size() > 0 {
+			prog = &current
+			ratio := current.Ratio()
+			if m.ProgressBar.Percent() != ratio {
+				progCmd = m.ProgressBar.SetPercent(ratio)
+			}
+		}
+		m.completed = current.Complete()
+		m.state = StateOf(current)
+		if current.Error() != nil && !errors.Is(current.Error(), progress.ErrCompleted) {
+			m.err = current.Error()
+		}
+		active = current.Current() > 0 || current.Complete() || current.Error() != nil
+	}
+	m.progress = prog
+
+	if !m.state.IsTerminal() && m.canceled() {
+		// the producer may not have noticed the cancellation (yet), but there is nothing more to wait on
+		m.state = StateCanceled
+		m.err = context.Canceled
+	}
+	if m.state.IsTerminal() {
+		// canceled tasks (including those that failed along with the cancellation) are done, even if incomplete
+		m.completed = true
+	}
+
+	if m.refreshStage() {
+		active = true
+	}
+
+	if m.starter != nil && m.starter.Started() {
+		active = true
+	}
+
+	now := time.Now()
+	m.refreshAttempt(now)
+	if m.attempt.Number > 0 {
+		active = true
+	}
+
+	m.started = m.started || active
+	if m.ShowPending && !m.started && !m.state.IsTerminal() {
+		m.state = StatePending
+	}
+
+	m.adapt(observe(current, m.stage))
+
+	if m.progressor != nil && !m.completed {
+		m.sparkline = m.sparkline.observe(current.Current(), now)
+	}
+	if m.startedAt.IsZero() && m.state != StatePending {
+		m.startedAt = now
+	}
+	if m.state.IsTerminal() {
+		if m.finishedAt.IsZero() {
+			m.finishedAt = now
+		}
+		m.handle.finish(m.state, m.err)
 	}
 
+	m.title = m.TitleOptions.Default
+	if m.progressor != nil || m.state == StatePending {
+		m.title = m.TitleOptions.forState(m.state)
+	}
+
+	m.handle.report(m.status(current))
+
+	return progCmd
+}
+
+func (m Model) status(current progress.Progress) Status {
+	s := Status{
+		Title:      m.title,
+		State:      m.state,
+		StartedAt:  m.startedAt,
+		FinishedAt: m.finishedAt,
+		Current:    current.Current(),
+		Size:       current.Size(),
+		Hints:      append([]string(nil), m.hints...),
+		Err:        m.err,
+	}
+	if m.state == StateWarning {
+		s.Err = current.Error()
+	}
+	return s
+}
+
+// refreshStage reads the latest stage into the hints, returning true if the stage has changed since it was first
+// observed.
+func (m *Model) refreshStage() bool {
+	defer m.refreshLabels()
+
+	if m.stager == nil {
+		return false
+	}
+
+	stage := m.stager.Stage()
+	m.stage = stage
+
+	if m.firstStage == nil {
+		m.firstStage = &stage
+		return false
+	}
+	return *m.firstStage != stage
+}
+
+// refreshLabels evaluates the hints and context shown alongside the title, including any from providers.
+func (m *Model) refreshLabels() {
+	var hints []string
+	if m.stage != "" {
+		// TODO: how to deal with stages that have custom stats from the results of commands?
+		// TODO: list is awkward both in usage and display
+		hints = append(hints, m.stage)
+	}
+	hints = append(hints, m.Hints...)
+	if m.hintProvider != nil {
+		hints = append(hints, m.hintProvider.Hints()...)
+	}
+	m.hints = hints
+
+	context := append([]string(nil), m.Context...)
+	if m.contextProvider != nil {
+		context = append(context, m.contextProvider.Context()...)
+	}
+	m.context = context
+}
+
+func (m Model) IsVisible() bool {
+	// note: completing with warnings is not hidden, since there is something the user should know about
+	isDoneAndHidden := m.completed && m.HideOnSuccess && m.state != StateWarning
 	return !(isDoneAndHidden)
 }
 
 // View renders the model's view.
 func (m Model) View() string {
 	if !m.IsVisible() {
-		m.done()
 		return ""
 	}
-	beforeProgress := " "
-	if m.completed {
-		if m.err != nil {
-			beforeProgress += m.FailedStyle.Render(xMark) + " "
-		} else {
-			beforeProgress += m.SuccessStyle.Render(checkMark) + " "
-		}
-	} else {
-		beforeProgress += m.Spinner.View() + " "
-	}
 
-	if m.title != "" {
-		beforeProgress += m.TitleStyle.Width(m.TitleWidth).Align(lipgloss.Left).Render(m.title) + "  "
-	}
+	// force overflow to be ignored
+	line := lipgloss.NewStyle().Inline(true).Render(layoutLine(m.WindowSize.Width, m.lineSegments()))
 
-	progressBar := ""
-	var progressBarWidth int
-	showProgress := m.progress != nil && (!m.completed || (m.completed && !m.HideProgressOnSuccess && m.err == nil))
-	if showProgress {
-		progressBar += m.ProgressBar.View() + "  "
-		progressBarWidth = m.ProgressBar.Width + 2
+	if m.showLogTail() {
+		if tail := m.renderLogTail(); tail != "" {
+			line += "\n" + tail
+		}
 	}
 
-	afterProgress := ""
-
-	showStage := (!m.completed || (m.completed && !m.HideStageOnSuccess)) && len(m.hints) > 0
-	if showStage {
-		var hints []string
-		for _, h := range m.hints {
-			hints = append(hints, fmt.Sprintf("%s%s%s", m.hintCap(false), h, m.hintCap(true)))
+	if m.showErrorDetail() {
+		if detail := m.renderErrorDetail(); detail != "" {
+			line += "\n" + detail
 		}
-		hintStr := strings.Join(hints, " ")
-		afterProgress += m.HintStyle.Render(hintStr) + "  "
 	}
+	return line
+}
 
-	if len(m.context) > 0 {
-		width := m.WindowSize.Width - (len(stripansi.Strip(beforeProgress+afterProgress)) + progressBarWidth)
-		afterProgress += m.ContextStyle.Width(width).Align(lipgloss.Right).Render(strings.Join(m.context, " "))
+// lineSegments returns all segments that should be shown on the task line, in display order.
+func (m Model) lineSegments() []segment {
+	var segments []segment
+	for _, s := range m.Layout.Segments() {
+		if seg, ok := m.buildSegment(s); ok {
+			segments = append(segments, seg)
+		}
 	}
+	return segments
+}
 
-	if m.completed {
-		defer m.done()
+// icon renders the state icon, using the given style instead of the state style when provided.
+func (m Model) icon(override *lipgloss.Style) string {
+	var (
+		glyph string
+		style lipgloss.Style
+	)
+	switch {
+	case !m.completed && m.state == StatePending:
+		glyph, style = m.glyphs.Pending, m.PendingStyle
+	case !m.completed:
+		spin := m.Spinner
+		if override != nil {
+			spin.Style = *override
+		}
+		return spin.View()
+	case m.state == StateCanceled:
+		glyph, style = m.glyphs.Canceled, m.CanceledStyle
+	case m.state == StateFailed:
+		glyph, style = m.glyphs.Failed, m.FailedStyle
+	case m.state == StateWarning:
+		glyph, style = m.glyphs.Warning, m.WarningStyle
+	default:
+		glyph, style = m.glyphs.Success, m.SuccessStyle
 	}
-
-	// force overflow to be ignored
-	return lipgloss.NewStyle().Inline(true).Render(beforeProgress + progressBar + afterProgress)
+	if override != nil {
+		style = *override
+	}
+	return style.Render(glyph)
 }
 
 func (m Model) queueNextTick(id, sequence int) tea.Cmd {
-	return tea.Tick(m.UpdateDuration, func(t time.Time) tea.Msg {
+	if m.scheduler != nil || m.stopPolling() {
+		// the scheduler is responsible for all ticks, or there is nothing left to poll for
+		return nil
+	}
+	return tea.Tick(m.tickInterval(), func(t time.Time) tea.Msg {
 		return TickMsg{
 			Time:     t,
 			ID:       id,
@@ -310,26 +548,52 @@ func (m Model) queueNextTick(id, sequence int) tea.Cmd {
 	})
 }
 
-func (m *Model) handleTick(msg TickMsg) tea.Cmd {
+func (m *Model) handleTick(msg TickMsg) bool {
 	// If an ID is set, and the ID doesn't belong to this spinner, reject
 	// the message.
 	if msg.ID > 0 && msg.ID != m.id {
-		return nil
+		return false
 	}
 
 	// If a sequence is set, and it's not the one we expect, reject the message.
 	// This prevents the spinner from receiving too many messages and
 	// thus spinning too fast.
 	if msg.Sequence > 0 && msg.Sequence != m.sequence {
-		return nil
+		return false
 	}
 
 	m.sequence++
 
-	// we should still respond to stage changes and window size events
-	// if m.completed {
-	//	return nil
-	//}
+	return true
+}
+
+func (m Model) handleSchedulerTick(msg SchedulerTickMsg) (tea.Model, tea.Cmd) {
+	if m.scheduler == nil || msg.ID != m.scheduler.ID() {
+		return m, nil
+	}
+
+	next := m.scheduler.next(msg)
+
+	if !m.scheduler.isRegistered(m.id) {
+		// completed models no longer keep the scheduler running (any stage changes were already picked up in Update)
+		return m, next
+	}
+
+	// advance the spinner a single frame, ignoring the timer the spinner would otherwise start for itself
+	m.Spinner, _ = m.Spinner.Update(spinner.TickMsg{
+		Time: msg.Time,
+		ID:   m.Spinner.ID(),
+	})
+
+	if m.isAdaptive() && msg.Time.Before(m.nextPoll) {
+		// nothing has been changing recently, so skip polling until the backoff has elapsed
+		return m, next
+	}
+	progCmd := m.refresh()
+	m.nextPoll = msg.Time.Add(m.tickInterval())
+	if m.stopPolling() {
+		m.scheduler.unregister(m.id)
+	}
 
-	return m.queueNextTick(m.id, m.sequence)
+	return m, tea.Batch(next, progCmd)
 }
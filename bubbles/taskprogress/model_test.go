@@ -8,12 +8,25 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/wagoodman/go-progress"
 
+	"github.com/anchore/bubbly/bubbles/glyphs"
 	"github.com/anchore/bubbly/bubbles/internal/testutil"
 )
 
+// update applies a message to the model, returning the updated model.
+func update(m Model, msg tea.Msg) Model {
+	next, _ := m.Update(msg)
+	return next.(Model)
+}
+
+// tick delivers a tick meant for the model, refreshing it from its progressor.
+func tick(m Model) Model {
+	return update(m, TickMsg{Time: time.Now(), ID: m.ID(), Sequence: m.Sequence()})
+}
+
 func subject(t testing.TB) (*progress.Manual, *progress.Stage, Model) {
 	return subjectWaitGroup(t, &sync.WaitGroup{})
 }
@@ -37,6 +50,7 @@ func subjectWaitGroup(_ testing.TB, wg *sync.WaitGroup) (*progress.Manual, *prog
 			Stager:       stage,
 			Progressable: prog,
 		})),
+		WithGlyphs(glyphs.Unicode),
 		WithNoStyle(),
 	)
 	tsk.HideProgressOnSuccess = true
@@ -80,6 +94,25 @@ func TestModel_View(t *testing.T) {
 				return tsk
 			},
 		},
+		{
+			name: "ascii glyphs in progress",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.N, prog.Total = 40, 100
+				WithGlyphs(glyphs.ASCII)(&tsk)
+				return tsk
+			},
+		},
+		{
+			name: "ascii glyphs on failure",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.N, prog.Total = 100, 100
+				prog.Err = errors.New("woops")
+				WithGlyphs(glyphs.ASCII)(&tsk)
+				return tsk
+			},
+		},
 		{
 			name: "respond to title width",
 			taskGen: func(tb testing.TB) Model {
@@ -150,6 +183,27 @@ func TestModel_View(t *testing.T) {
 				return tsk
 			},
 		},
+		{
+			name: "completed with warnings",
+			taskGen: func(tb testing.TB) Model {
+				prog, stage, tsk := subject(t)
+				prog.N, prog.Total = 100, 100
+				prog.Err = CompletedWithWarnings(errors.New("unable to read file"))
+				stage.Current = "done!"
+				tsk.TitleOptions.Warning = "Did work (with warnings)"
+				return tsk
+			},
+		},
+		{
+			name: "completed with warnings is not hidden",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				tsk.HideOnSuccess = true
+				prog.N, prog.Total = 100, 100
+				prog.Err = CompletedWithWarnings()
+				return tsk
+			},
+		},
 		{
 			name: "error",
 			taskGen: func(tb testing.TB) Model {
@@ -160,6 +214,150 @@ func TestModel_View(t *testing.T) {
 				return tsk
 			},
 		},
+		{
+			name: "error detail on completion",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.SetCompleted()
+				prog.Err = errors.New("unable to open the image: the file could not be found on disk, or it has been moved since the task started running")
+				tsk.WindowSize.Width = 60
+				tsk.ErrorDetail = ErrorDetailOnCompletion
+				return tsk
+			},
+		},
+		{
+			name: "error detail with multiple errors",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.SetCompleted()
+				prog.Err = errors.Join(errors.New("first woops"), errors.New("second woops"))
+				tsk.ErrorDetail = ErrorDetailAlways
+				return tsk
+			},
+		},
+		{
+			name: "error detail not shown while running",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.Err = errors.New("woops")
+				tsk.ErrorDetail = ErrorDetailOnCompletion
+				return tsk
+			},
+		},
+		{
+			name: "error detail always shown while running",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.N, prog.Total = 3, 10
+				prog.Err = errors.New("disk unreadable")
+				tsk.ErrorDetail = ErrorDetailAlways
+				return tsk
+			},
+		},
+		{
+			name: "narrow window removes title padding",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.N, prog.Total = 40, 100
+				tsk.WindowSize.Width = 60
+				return tsk
+			},
+		},
+		{
+			name: "narrow window drops context and hints",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.N, prog.Total = 40, 100
+				tsk.WindowSize.Width = 30
+				return tsk
+			},
+		},
+		{
+			name: "very narrow window truncates title",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.N, prog.Total = 40, 100
+				tsk.WindowSize.Width = 10
+				return tsk
+			},
+		},
+		{
+			name: "wide characters are measured by cell width",
+			taskGen: func(tb testing.TB) Model {
+				_, _, tsk := subject(t)
+				tsk.TitleOptions.Running = "処理中のタスク"
+				tsk.Context = []string{"コンテキスト"}
+				return tsk
+			},
+		},
+		{
+			name: "long title is truncated to the title width",
+			taskGen: func(tb testing.TB) Model {
+				_, _, tsk := subject(t)
+				tsk.TitleOptions.Running = "Doing a whole lot of work with an incredibly long title"
+				return tsk
+			},
+		},
+		{
+			name: "pending until there is activity",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.N, prog.Total = 0, 100
+				tsk.ShowPending = true
+				tsk.TitleOptions.Pending = "Waiting to work"
+				return tsk
+			},
+		},
+		{
+			name: "pending ends once progress is reported",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.N, prog.Total = 1, 100
+				tsk.ShowPending = true
+				tsk.TitleOptions.Pending = "Waiting to work"
+				return tsk
+			},
+		},
+		{
+			name: "log tail while running",
+			taskGen: func(tb testing.TB) Model {
+				_, _, tsk := subject(t)
+				WithLogTail(3)(&tsk)
+				_, _ = tsk.LogWriter().Write([]byte("step 1/4\nstep 2/4\nstep 3/4\ndownloading 10%\rdownloading 50%\n\tpartial line"))
+				return tsk
+			},
+		},
+		{
+			name: "log tail collapses on success",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.N, prog.Total = 100, 100
+				WithLogTail(3)(&tsk)
+				_, _ = tsk.LogWriter().Write([]byte("step 1/2\nstep 2/2\n"))
+				return tsk
+			},
+		},
+		{
+			name: "log tail kept on failure",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(t)
+				prog.SetCompleted()
+				prog.Err = errors.New("woops")
+				tsk.ErrorDetail = ErrorDetailOnCompletion
+				WithLogTail(3)(&tsk)
+				_, _ = tsk.LogWriter().Write([]byte("step 1/2\nstep 2/2 failed!\n"))
+				return tsk
+			},
+		},
+		{
+			name: "no log tail when the line count is not positive",
+			taskGen: func(tb testing.TB) Model {
+				_, _, tsk := subject(t)
+				WithLogTail(0)(&tsk)
+				_, _ = tsk.LogWriter().Write([]byte("step 1/2\nstep 2/2\n"))
+				return tsk
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -177,6 +375,70 @@ func TestModel_View(t *testing.T) {
 	}
 }
 
+func TestModel_ErrorDetailToggle(t *testing.T) {
+	prog, _, tsk := subject(t)
+	prog.SetCompleted()
+	prog.Err = errors.New("woops")
+	tsk.ErrorDetail = ErrorDetailToggle
+
+	toggle := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")}
+
+	tsk = tick(tsk)
+	assert.NotContains(t, tsk.View(), "woops")
+
+	tsk = update(tsk, toggle)
+	assert.Contains(t, tsk.View(), "woops")
+
+	tsk = update(tsk, toggle)
+	assert.NotContains(t, tsk.View(), "woops")
+}
+
+type startSignal struct {
+	progress.Manual
+	started bool
+}
+
+func (s *startSignal) Started() bool {
+	return s.started
+}
+
+func TestModel_PendingState(t *testing.T) {
+	prog := &startSignal{Manual: progress.Manual{Total: -1}}
+	stage := &progress.Stage{Current: "queued"}
+	tsk := New(&sync.WaitGroup{}, WithProgress(prog), WithStager(stage), WithPending())
+	require.Equal(t, StatePending, tsk.State())
+
+	tsk = tick(tsk)
+	assert.Equal(t, StatePending, tsk.State())
+
+	prog.started = true
+	tsk = tick(tsk)
+	assert.Equal(t, StateRunning, tsk.State())
+
+	// once started, the task never goes back to pending
+	prog.started = false
+	tsk = tick(tsk)
+	assert.Equal(t, StateRunning, tsk.State())
+}
+
+func TestModel_PendingEndsOnStageChange(t *testing.T) {
+	stage := &progress.Stage{Current: "queued"}
+	tsk := New(&sync.WaitGroup{}, WithStagedProgressable(&struct {
+		progress.Stager
+		progress.Progressable
+	}{
+		Stager:       stage,
+		Progressable: &progress.Manual{Total: -1},
+	}), WithPending())
+
+	tsk = tick(tsk)
+	assert.Equal(t, StatePending, tsk.State())
+
+	stage.Current = "working"
+	tsk = tick(tsk)
+	assert.Equal(t, StateRunning, tsk.State())
+}
+
 func Test_WaitGroupDone(t *testing.T) {
 	waitGroupDone := func(_ Model, wg *sync.WaitGroup) {
 		wg.Wait()
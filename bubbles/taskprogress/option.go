@@ -1,8 +1,14 @@
 package taskprogress
 
 import (
+	"context"
+	"time"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/bubbly/bubbles/glyphs"
+	"github.com/anchore/bubbly/bubbles/internal/linebuffer"
 )
 
 type Option func(*Model)
@@ -10,12 +16,24 @@ type Option func(*Model)
 func WithProgress(prog progress.Progressable) Option {
 	return func(m *Model) {
 		m.progressor = progress.NewGenerator(prog, prog)
+		if s, ok := prog.(Starter); ok {
+			m.starter = s
+		}
+		if r, ok := prog.(Retrier); ok {
+			m.retrier = r
+		}
 	}
 }
 
 func WithStager(s progress.Stager) Option {
 	return func(m *Model) {
 		m.stager = s
+		if st, ok := s.(Starter); ok {
+			m.starter = st
+		}
+		if r, ok := s.(Retrier); ok {
+			m.retrier = r
+		}
 	}
 }
 
@@ -26,16 +44,135 @@ func WithStagedProgressable(s progress.StagedProgressable) Option {
 	}
 }
 
+// WithHints adds hints that are evaluated each time the task is refreshed (after any stage and static Hints).
+func WithHints(p HintProvider) Option {
+	return func(m *Model) {
+		m.hintProvider = p
+	}
+}
+
+// WithContext adds context that is evaluated each time the task is refreshed (after any static Context).
+func WithContext(p ContextProvider) Option {
+	return func(m *Model) {
+		m.contextProvider = p
+	}
+}
+
+// WithCancel allows the user to cancel the task (see CancelKey) while it is focused. The given context should be the
+// one the task's work is running under: once it is canceled the task is shown as canceled, even if the producer
+// never reports context.Canceled itself.
+func WithCancel(ctx context.Context, cancel context.CancelFunc) Option {
+	return func(m *Model) {
+		m.cancelCtx = ctx
+		m.cancelFunc = cancel
+	}
+}
+
+// WithRetrier reports the attempts made at the work (this is not needed if the progress producer is a Retrier).
+func WithRetrier(r Retrier) Option {
+	return func(m *Model) {
+		m.retrier = r
+	}
+}
+
 func WithNoStyle() Option {
 	return func(m *Model) {
 		m.SuccessStyle = lipgloss.NewStyle()
+		m.WarningStyle = lipgloss.NewStyle()
 		m.ContextStyle = lipgloss.NewStyle()
 		m.FailedStyle = lipgloss.NewStyle()
+		m.CanceledStyle = lipgloss.NewStyle()
 		m.HintStyle = lipgloss.NewStyle()
+		m.ErrorDetailStyle = lipgloss.NewStyle()
+		m.LogTailStyle = lipgloss.NewStyle()
+		m.SparklineStyle = lipgloss.NewStyle()
 		m.TitleStyle = lipgloss.NewStyle()
+		m.TitlePendingStyle = lipgloss.NewStyle()
+		m.PendingStyle = lipgloss.NewStyle()
 		m.ProgressBar.FullColor = ""
 		m.ProgressBar.EmptyColor = ""
 		m.ProgressBar.Full = '|'
 		m.ProgressBar.Empty = '-'
 	}
 }
+
+// WithGlyphs selects the spinner, state icons and progress bar characters (by default these are detected from the
+// terminal, see glyphs.Detect).
+func WithGlyphs(set glyphs.Set) Option {
+	return func(m *Model) {
+		m.glyphs = set
+		m.Spinner.Spinner = set.Spinner
+		m.ProgressBar.Full = set.BarFull
+		m.ProgressBar.Empty = set.BarEmpty
+	}
+}
+
+// WithCompletion controls what happens to the task in a live frame once it finishes.
+func WithCompletion(c Completion) Option {
+	return func(m *Model) {
+		m.Completion = c
+	}
+}
+
+// WithErrorDetail controls when the error text of a failed task is shown beneath the task line.
+func WithErrorDetail(mode ErrorDetail) Option {
+	return func(m *Model) {
+		m.ErrorDetail = mode
+	}
+}
+
+// WithPending shows the task as pending (rather than running) until the progressor reports activity, the stage
+// changes, or the producer signals that it has started (see Starter).
+func WithPending() Option {
+	return func(m *Model) {
+		m.ShowPending = true
+		m.state = StatePending
+	}
+}
+
+// WithScheduler drives the model from a shared Scheduler instead of per-model timers.
+func WithScheduler(s *Scheduler) Option {
+	return func(m *Model) {
+		m.scheduler = s
+	}
+}
+
+// WithAdaptiveRefresh polls the progressor between the given minimum and maximum intervals (instead of a fixed
+// UpdateDuration): polling backs off while nothing changes, returns to the minimum as soon as values change, and
+// stops entirely once the task is complete.
+func WithAdaptiveRefresh(minInterval, maxInterval time.Duration) Option {
+	return func(m *Model) {
+		m.refreshMin = minInterval
+		m.refreshMax = max(minInterval, maxInterval)
+	}
+}
+
+// WithLayout sets the segments that make up the task line (see ParseLayout and NewLayout).
+func WithLayout(l Layout) Option {
+	return func(m *Model) {
+		m.Layout = l
+	}
+}
+
+// WithLogTail retains the last n lines written to the model's LogWriter and shows them beneath the task line while
+// the task is running. The lines are hidden once the task succeeds, but are kept if the task fails. There is no log
+// tail when n is not positive.
+func WithLogTail(n int) Option {
+	return func(m *Model) {
+		if n <= 0 {
+			m.logs = nil
+			m.logTailLines = 0
+			return
+		}
+		m.logs = linebuffer.New(n, 0)
+		m.logTailLines = n
+	}
+}
+
+// WithSparkline plots the throughput of the task over the most recent refreshes next to the progress bar, using
+// one cell per sample.
+func WithSparkline(window int) Option {
+	return func(m *Model) {
+		m.sparkline = newSparkline(window)
+	}
+}
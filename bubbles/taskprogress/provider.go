@@ -0,0 +1,74 @@
+package taskprogress
+
+import (
+	"sync"
+)
+
+// HintProvider supplies hints (shown after the title) that are evaluated each time the task is refreshed. This allows
+// producers to show live values, such as "312 packages", without reaching into the model. Implementations are called
+// from the UI goroutine, so must be safe to call concurrently with the producer.
+type HintProvider interface {
+	Hints() []string
+}
+
+// ContextProvider supplies context (shown at the end of the task line) that is evaluated each time the task is
+// refreshed. The same concurrency requirements as HintProvider apply.
+type ContextProvider interface {
+	Context() []string
+}
+
+// HintFunc adapts a function to a HintProvider.
+type HintFunc func() []string
+
+func (f HintFunc) Hints() []string {
+	return f()
+}
+
+// ContextFunc adapts a function to a ContextProvider.
+type ContextFunc func() []string
+
+func (f ContextFunc) Context() []string {
+	return f()
+}
+
+var (
+	_ HintProvider    = (*Labels)(nil)
+	_ ContextProvider = (*Labels)(nil)
+)
+
+// Labels is a set of values that producers can update from any goroutine while the UI reads them. It can be used as
+// either a HintProvider or a ContextProvider.
+type Labels struct {
+	lock   *sync.RWMutex
+	values []string
+}
+
+func NewLabels(values ...string) *Labels {
+	l := &Labels{lock: &sync.RWMutex{}}
+	l.Set(values...)
+	return l
+}
+
+// Set replaces all values.
+func (l *Labels) Set(values ...string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.values = append([]string(nil), values...)
+}
+
+// Values returns a copy of the current values.
+func (l *Labels) Values() []string {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return append([]string(nil), l.values...)
+}
+
+func (l *Labels) Hints() []string {
+	return l.Values()
+}
+
+func (l *Labels) Context() []string {
+	return l.Values()
+}
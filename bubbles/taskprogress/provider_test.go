@@ -0,0 +1,82 @@
+package taskprogress
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/bubbly/bubbles/glyphs"
+	"github.com/anchore/bubbly/bubbles/internal/testutil"
+)
+
+func TestModel_Providers(t *testing.T) {
+	var packages atomic.Int64
+	prog, _, tsk := subject(t)
+	prog.N, prog.Total = 40, -1
+	WithHints(HintFunc(func() []string {
+		return []string{fmt.Sprintf("%d packages", packages.Load())}
+	}))(&tsk)
+	context := NewLabels("from cache")
+	WithContext(context)(&tsk)
+
+	tsk = tick(tsk)
+	assert.Equal(t, []string{"working", "0 packages"}, tsk.hints)
+	assert.Equal(t, []string{"at home", "from cache"}, tsk.context)
+
+	packages.Store(312)
+	context.Set("from registry")
+	tsk = tick(tsk)
+	assert.Equal(t, []string{"working", "312 packages"}, tsk.hints)
+	assert.Equal(t, []string{"at home", "from registry"}, tsk.context)
+}
+
+func TestModel_View_Providers(t *testing.T) {
+	prog := &progress.Manual{N: 40, Total: -1}
+	tsk := New(&sync.WaitGroup{},
+		WithProgress(prog),
+		WithHints(NewLabels("312 packages")),
+		WithContext(ContextFunc(func() []string { return []string{"docker.io/library/alpine"} })),
+		WithGlyphs(glyphs.Unicode),
+		WithNoStyle(),
+	)
+	tsk.TitleOptions = Title{Default: "Catalog packages"}
+	tsk.WindowSize.Width = 100
+
+	// note: hints from providers are shown even without a stager
+	got := testutil.RunModel(t, tsk, 1, TickMsg{
+		Time:     time.Now(),
+		Sequence: tsk.sequence,
+		ID:       tsk.id,
+	})
+	t.Log(got)
+	snaps.MatchSnapshot(t, got)
+}
+
+func TestLabels_ConcurrentAccess(t *testing.T) {
+	labels := NewLabels()
+	prog, _, tsk := subject(t)
+	prog.N, prog.Total = 1, 10
+	WithHints(labels)(&tsk)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			labels.Set(fmt.Sprintf("%d packages", i))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		tsk = tick(tsk)
+	}
+	wg.Wait()
+
+	tsk = tick(tsk)
+	assert.Equal(t, []string{"working", "999 packages"}, tsk.hints)
+}
@@ -0,0 +1,164 @@
+package taskprogress
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Retrier can be implemented by progress producers (the value given to WithProgress or WithStager, or given
+// explicitly with WithRetrier) to report which attempt the work is on when it is retried. Producers are expected to
+// reset their progress at the start of each attempt; the progress bar is hidden while waiting to retry, so that it
+// starts over with the next attempt.
+//
+// A failed attempt does not finish the task while attempts remain: the task is retrying until an attempt succeeds or
+// the last attempt fails. Producers that stop retrying early (or that retry without a bound) must report the current
+// attempt as the last one before failing (see RetryTracker.GiveUp).
+type Retrier interface {
+	Attempt() Attempt
+}
+
+// Attempt describes the most recent attempt at the work.
+type Attempt struct {
+	// Number is the 1-based number of the attempt (zero if the work has not been attempted yet).
+	Number int
+	// Max is the number of attempts that will be made (zero if unbounded).
+	Max int
+	// RetryAt is when the next attempt will start, set only while waiting to retry after the attempt failed.
+	RetryAt time.Time
+	// Err is the error the attempt failed with.
+	Err error
+}
+
+func (a Attempt) String() string {
+	if a.Max > 0 {
+		return fmt.Sprintf("attempt %d/%d", a.Number, a.Max)
+	}
+	return fmt.Sprintf("attempt %d", a.Number)
+}
+
+// waiting indicates that the attempt failed and the next attempt has not started yet.
+func (a Attempt) waiting(now time.Time) bool {
+	return !a.RetryAt.IsZero() && now.Before(a.RetryAt)
+}
+
+var _ Retrier = (*RetryTracker)(nil)
+
+// RetryTracker is a Retrier that producers can update from any goroutine as they retry work.
+type RetryTracker struct {
+	lock    *sync.RWMutex
+	attempt Attempt
+}
+
+// NewRetryTracker returns a tracker for work that will be attempted at most max times (zero if unbounded).
+func NewRetryTracker(max int) *RetryTracker {
+	return &RetryTracker{
+		lock:    &sync.RWMutex{},
+		attempt: Attempt{Max: max},
+	}
+}
+
+// Begin records the start of the next attempt, returning its number.
+func (r *RetryTracker) Begin() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.attempt = Attempt{
+		Number: r.attempt.Number + 1,
+		Max:    r.attempt.Max,
+	}
+	return r.attempt.Number
+}
+
+// Backoff records that the current attempt failed with the given error, and that the next attempt will start after
+// the given delay.
+func (r *RetryTracker) Backoff(err error, delay time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.attempt.Err = err
+	r.attempt.RetryAt = time.Now().Add(delay)
+}
+
+// GiveUp records that the current attempt is the last one (e.g. the error is not worth retrying), so that the task
+// fails along with it.
+func (r *RetryTracker) GiveUp() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.attempt.Max = r.attempt.Number
+	r.attempt.RetryAt = time.Time{}
+}
+
+func (r *RetryTracker) Attempt() Attempt {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.attempt
+}
+
+// Attempts returns the attempts that were made before the current one (as observed by the model), oldest first.
+func (m Model) Attempts() []Attempt {
+	return append([]Attempt(nil), m.attemptHistory...)
+}
+
+// refreshAttempt picks up the most recent attempt from the producer, keeping a history of previous attempts.
+func (m *Model) refreshAttempt(now time.Time) {
+	if m.retrier == nil {
+		return
+	}
+
+	attempt := m.retrier.Attempt()
+	if m.attempt.Number > 0 && attempt.Number != m.attempt.Number {
+		// the work is being retried, the previous attempt is kept for the record
+		m.attemptHistory = append(append([]Attempt(nil), m.attemptHistory...), m.attempt)
+	}
+	m.attempt = attempt
+
+	switch {
+	case m.mayRetry():
+		// the attempt failed, but the work will be attempted again so the task is not finished yet
+		m.state = StateRetrying
+		m.completed = false
+	case !m.state.IsTerminal() && attempt.waiting(now):
+		m.state = StateRetrying
+	}
+}
+
+// mayRetry indicates that the most recent attempt failed, but it was not the last attempt.
+func (m Model) mayRetry() bool {
+	if m.state != StateFailed || m.attempt.Number == 0 {
+		return false
+	}
+	return m.attempt.Max <= 0 || m.attempt.Number < m.attempt.Max
+}
+
+// retryHint describes the current attempt, shown only once the work has been retried (or is about to be).
+func (m Model) retryHint() string {
+	if m.retrier == nil || m.completed || m.attempt.Number == 0 {
+		return ""
+	}
+	if m.state == StateRetrying {
+		if wait := time.Until(m.attempt.RetryAt); wait > 0 {
+			return fmt.Sprintf("%s, retrying in %ds", m.attempt, int(math.Ceil(wait.Seconds())))
+		}
+	}
+	if m.attempt.Number > 1 {
+		return m.attempt.String()
+	}
+	return ""
+}
+
+// attemptMessages describes how each previous attempt failed.
+func (m Model) attemptMessages() []string {
+	var msgs []string
+	for _, a := range m.attemptHistory {
+		if a.Err == nil {
+			msgs = append(msgs, fmt.Sprintf("%s failed", a))
+			continue
+		}
+		msgs = append(msgs, fmt.Sprintf("%s failed: %s", a, a.Err))
+	}
+	return msgs
+}
@@ -0,0 +1,195 @@
+package taskprogress
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/bubbly/bubbles/glyphs"
+	"github.com/anchore/bubbly/bubbles/internal/testutil"
+)
+
+type retriedProgress struct {
+	*progress.Manual
+	*RetryTracker
+}
+
+func TestAttempt_String(t *testing.T) {
+	assert.Equal(t, "attempt 2/5", Attempt{Number: 2, Max: 5}.String())
+	assert.Equal(t, "attempt 3", Attempt{Number: 3}.String())
+}
+
+func TestRetryTracker(t *testing.T) {
+	r := NewRetryTracker(3)
+	assert.Equal(t, Attempt{Max: 3}, r.Attempt())
+
+	assert.Equal(t, 1, r.Begin())
+	timeout := errors.New("timeout")
+	r.Backoff(timeout, time.Minute)
+
+	got := r.Attempt()
+	assert.Equal(t, 1, got.Number)
+	assert.Equal(t, timeout, got.Err)
+	assert.True(t, got.waiting(time.Now()))
+
+	assert.Equal(t, 2, r.Begin())
+	assert.Equal(t, Attempt{Number: 2, Max: 3}, r.Attempt())
+}
+
+func TestTitle_Retrying(t *testing.T) {
+	assert.Equal(t, "retrying", Title{Default: "default", Running: "running", Retrying: "retrying"}.forState(StateRetrying))
+	assert.Equal(t, "running", Title{Default: "default", Running: "running"}.forState(StateRetrying))
+	assert.Equal(t, "default", Title{Default: "default"}.forState(StateRetrying))
+	assert.False(t, StateRetrying.IsTerminal())
+}
+
+func TestModel_Retry(t *testing.T) {
+	prog := &progress.Manual{Total: 10}
+	tracker := NewRetryTracker(5)
+	tsk := New(&sync.WaitGroup{}, WithProgress(retriedProgress{Manual: prog, RetryTracker: tracker}))
+
+	tracker.Begin()
+	prog.N = 6
+	tsk = tick(tsk)
+	assert.Equal(t, StateRunning, tsk.State())
+	assert.Empty(t, tsk.retryHint(), "the first attempt is not called out")
+
+	timeout := errors.New("timeout")
+	tracker.Backoff(timeout, 3*time.Second)
+	tsk = tick(tsk)
+	assert.Equal(t, StateRetrying, tsk.State())
+	assert.Equal(t, "attempt 1/5, retrying in 3s", tsk.retryHint())
+	assert.False(t, tsk.showProgress())
+
+	tracker.Begin()
+	prog.N = 1
+	tsk = tick(tsk)
+	assert.Equal(t, StateRunning, tsk.State())
+	assert.Equal(t, "attempt 2/5", tsk.retryHint())
+	assert.True(t, tsk.showProgress())
+	require.Len(t, tsk.Attempts(), 1)
+	assert.Equal(t, timeout, tsk.Attempts()[0].Err)
+
+	// a failed attempt does not fail the task while attempts remain...
+	prog.N = 10
+	prog.Err = errors.New("unauthorized")
+	tsk = tick(tsk)
+	assert.Equal(t, StateRetrying, tsk.State())
+	assert.False(t, tsk.IsCompleted())
+
+	// ...unless the producer gives up
+	tracker.GiveUp()
+	tsk = tick(tsk)
+	assert.Equal(t, StateFailed, tsk.State())
+	assert.True(t, tsk.IsCompleted())
+	assert.Empty(t, tsk.retryHint())
+	assert.Equal(t, []string{"attempt 1/5 failed: timeout"}, tsk.attemptMessages())
+}
+
+func TestModel_RetrySucceedsAfterFailedAttempt(t *testing.T) {
+	prog := &progress.Manual{Total: 10}
+	tracker := NewRetryTracker(3)
+	wg := &sync.WaitGroup{}
+	tsk := New(wg, WithProgress(retriedProgress{Manual: prog, RetryTracker: tracker}), WithCompletion(CompletionImprint))
+	handle := tsk.Handle()
+	WithGlyphs(glyphs.Unicode)(&tsk)
+	WithNoStyle()(&tsk)
+	tsk.ErrorDetail = ErrorDetailAlways
+
+	// the first attempt runs to completion, but fails
+	tracker.Begin()
+	prog.N = 10
+	prog.Err = errors.New("attempt failed")
+	tsk = tick(tsk)
+
+	assert.Equal(t, StateRetrying, tsk.State())
+	assert.False(t, tsk.IsCompleted())
+	assert.False(t, tsk.ShouldImprint())
+	assert.NotNil(t, tsk.queueNextTick(tsk.id, tsk.sequence), "the task must still be polled")
+	select {
+	case <-handle.Done():
+		t.Fatal("the handle must not finish while attempts remain")
+	default:
+	}
+
+	tracker.Backoff(prog.Err, time.Second)
+	tsk = tick(tsk)
+	require.Equal(t, StateRetrying, tsk.State())
+
+	tracker.Begin()
+	prog.Err = nil
+	prog.SetCompleted()
+	tsk = tick(tsk)
+
+	assert.Equal(t, StateSuccess, tsk.State())
+	assert.False(t, tsk.IsFailed())
+	assert.True(t, strings.HasPrefix(strings.TrimSpace(tsk.View()), glyphs.Unicode.Success), tsk.View())
+	assert.NotContains(t, tsk.View(), "attempt failed")
+	assert.Equal(t, StateSuccess, handle.State())
+	assert.NoError(t, handle.Err())
+	wg.Wait()
+}
+
+func TestModel_View_Retry(t *testing.T) {
+	tests := []struct {
+		name    string
+		taskGen func(testing.TB) Model
+	}{
+		{
+			name: "waiting to retry",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 40, 100
+				WithRetrier(attemptFunc(Attempt{Number: 2, Max: 5, RetryAt: time.Now().Add(2500 * time.Millisecond)}))(&tsk)
+				return tsk
+			},
+		},
+		{
+			name: "retried",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 40, 100
+				WithRetrier(attemptFunc(Attempt{Number: 3, Max: 5}))(&tsk)
+				return tsk
+			},
+		},
+		{
+			name: "failed after retries",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 100, 100
+				prog.Err = errors.New("unauthorized")
+				WithRetrier(attemptFunc(Attempt{Number: 3, Max: 3}))(&tsk)
+				tsk.ErrorDetail = ErrorDetailAlways
+				tsk.attempt = Attempt{Number: 2, Max: 3, Err: errors.New("connection reset")}
+				tsk.attemptHistory = []Attempt{{Number: 1, Max: 3, Err: errors.New("timeout")}}
+				return tsk
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsk := tt.taskGen(t)
+			got := testutil.RunModel(t, tsk, 1, TickMsg{
+				Time:     time.Now(),
+				Sequence: tsk.sequence,
+				ID:       tsk.id,
+			})
+			t.Log(got)
+			snaps.MatchSnapshot(t, got)
+		})
+	}
+}
+
+type attemptFunc Attempt
+
+func (a attemptFunc) Attempt() Attempt {
+	return Attempt(a)
+}
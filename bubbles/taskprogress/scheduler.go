@@ -0,0 +1,117 @@
+package taskprogress
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SchedulerTickMsg is a single timer tick that is fanned out to every model registered with a Scheduler.
+type SchedulerTickMsg struct {
+	Time     time.Time
+	Sequence int
+	ID       int
+}
+
+// Scheduler drives the periodic updates of many models from a single timer. Without a scheduler every model keeps
+// its own update and spinner timers, which does not scale well to hundreds or thousands of tasks. Models are
+// registered with a scheduler via WithScheduler and are automatically unregistered once they complete (or when they
+// are released, see Model.Release); the scheduler stops ticking when there are no registered models and starts again
+// when a new model is initialized.
+type Scheduler struct {
+	id       int
+	interval time.Duration
+
+	lock     *sync.Mutex
+	members  map[int]struct{}
+	sequence int
+	running  bool
+}
+
+// NewScheduler returns a scheduler that ticks at the given interval. Since spinners are advanced by one frame per
+// tick, an interval close to the spinner FPS gives the smoothest result.
+func NewScheduler(interval time.Duration) *Scheduler {
+	return &Scheduler{
+		id:       nextID(),
+		interval: interval,
+		lock:     &sync.Mutex{},
+		members:  make(map[int]struct{}),
+	}
+}
+
+// ID returns the scheduler's unique ID.
+func (s *Scheduler) ID() int {
+	return s.id
+}
+
+// Len returns the number of models that are currently registered.
+func (s *Scheduler) Len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.members)
+}
+
+// register adds the model with the given ID, returning the command to start ticking if the scheduler is idle.
+func (s *Scheduler) register(id int) tea.Cmd {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.members[id] = struct{}{}
+	if s.running {
+		return nil
+	}
+	s.running = true
+	return s.tick(s.sequence)
+}
+
+func (s *Scheduler) unregister(id int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.members, id)
+	if len(s.members) == 0 && s.running {
+		// there is nothing left to tick: any tick still in flight is ignored, and the next model to register starts
+		// ticking again (which would not happen if the in-flight tick is never delivered, e.g. the model was removed)
+		s.running = false
+		s.sequence++
+	}
+}
+
+func (s *Scheduler) isRegistered(id int) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, ok := s.members[id]
+	return ok
+}
+
+// next returns the command for the following tick. Every registered model receives the same tick message, so only
+// the first caller for a given sequence gets a command; all other callers get nil.
+func (s *Scheduler) next(msg SchedulerTickMsg) tea.Cmd {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if msg.ID != s.id || msg.Sequence != s.sequence {
+		return nil
+	}
+	s.sequence++
+
+	if len(s.members) == 0 {
+		s.running = false
+		return nil
+	}
+	return s.tick(s.sequence)
+}
+
+func (s *Scheduler) tick(sequence int) tea.Cmd {
+	id := s.id
+	return tea.Tick(s.interval, func(t time.Time) tea.Msg {
+		return SchedulerTickMsg{
+			Time:     t,
+			ID:       id,
+			Sequence: sequence,
+		}
+	})
+}
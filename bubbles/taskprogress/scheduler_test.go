@@ -0,0 +1,171 @@
+package taskprogress
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/bubbly/bubbles/internal/testutil"
+)
+
+func scheduledModels(n int, s *Scheduler) ([]*progress.Manual, []tea.Model) {
+	wg := &sync.WaitGroup{}
+	var (
+		progs  []*progress.Manual
+		models []tea.Model
+	)
+	for i := 0; i < n; i++ {
+		prog := &progress.Manual{N: 1, Total: 10}
+		progs = append(progs, prog)
+		models = append(models, New(wg, WithProgress(prog), WithScheduler(s)))
+	}
+	return progs, models
+}
+
+// fanOut delivers the message to every model (as frame.Frame would), returning all resulting commands.
+func fanOut(models []tea.Model, msg tea.Msg) []tea.Cmd {
+	var cmds []tea.Cmd
+	for i, m := range models {
+		var cmd tea.Cmd
+		models[i], cmd = m.Update(msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds
+}
+
+func TestScheduler_RegistersOnInit(t *testing.T) {
+	s := NewScheduler(time.Millisecond)
+	_, models := scheduledModels(3, s)
+
+	assert.Equal(t, 0, s.Len())
+
+	var starts int
+	for _, m := range models {
+		// the progress bar has nothing to animate yet, so only the scheduler start is returned
+		if m.Init() != nil {
+			starts++
+		}
+	}
+
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, 1, starts, "only the first model should start the scheduler")
+}
+
+func TestScheduler_SingleTickPerInterval(t *testing.T) {
+	s := NewScheduler(time.Millisecond)
+	_, models := scheduledModels(5, s)
+	for _, m := range models {
+		m.Init()
+	}
+
+	tick := SchedulerTickMsg{Time: time.Now(), ID: s.ID(), Sequence: 0}
+
+	var nextTicks int
+	for _, m := range models {
+		_, cmd := m.Update(tick)
+		if cmd == nil {
+			continue
+		}
+		for _, msg := range testutil.Messages(cmd) {
+			if _, ok := msg.(SchedulerTickMsg); ok {
+				nextTicks++
+			}
+		}
+	}
+
+	assert.Equal(t, 1, nextTicks)
+}
+
+func TestScheduler_IgnoresOtherSchedulers(t *testing.T) {
+	s := NewScheduler(time.Millisecond)
+	other := NewScheduler(time.Millisecond)
+	_, models := scheduledModels(1, s)
+	models[0].Init()
+
+	_, cmd := models[0].Update(SchedulerTickMsg{Time: time.Now(), ID: other.ID()})
+	assert.Nil(t, cmd)
+}
+
+func TestScheduler_StopsTickingCompletedModels(t *testing.T) {
+	s := NewScheduler(time.Millisecond)
+	progs, models := scheduledModels(2, s)
+	for _, m := range models {
+		m.Init()
+	}
+
+	progs[0].SetCompleted()
+	fanOut(models, SchedulerTickMsg{Time: time.Now(), ID: s.ID(), Sequence: 0})
+	assert.Equal(t, 1, s.Len())
+
+	progs[1].SetCompleted()
+	fanOut(models, SchedulerTickMsg{Time: time.Now(), ID: s.ID(), Sequence: 1})
+	assert.Equal(t, 0, s.Len())
+
+	// the tick that was already scheduled is the last one
+	cmds := fanOut(models, SchedulerTickMsg{Time: time.Now(), ID: s.ID(), Sequence: 2})
+	assert.Empty(t, cmds, "the scheduler should stop when there is nothing left to tick")
+
+	// a new model restarts the scheduler
+	_, more := scheduledModels(1, s)
+	require.NotNil(t, more[0].Init())
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestScheduler_CompletedModelsRefreshLabelsOncePerTick(t *testing.T) {
+	s := NewScheduler(time.Millisecond)
+	var evaluated int
+	tsk := New(&sync.WaitGroup{},
+		WithProgress(&progress.Manual{N: 10, Total: 10, Err: progress.ErrCompleted}),
+		WithHints(HintFunc(func() []string {
+			evaluated++
+			return nil
+		})),
+		WithScheduler(s),
+	)
+	tsk.Init()
+
+	tsk = update(tsk, SchedulerTickMsg{Time: time.Now(), ID: s.ID(), Sequence: 0})
+	require.Equal(t, 0, s.Len())
+
+	evaluated = 0
+	update(tsk, SchedulerTickMsg{Time: time.Now(), ID: s.ID(), Sequence: 1})
+	assert.Equal(t, 1, evaluated)
+}
+
+func TestScheduler_AdvancesSpinner(t *testing.T) {
+	s := NewScheduler(time.Millisecond)
+	_, models := scheduledModels(1, s)
+	models[0].Init()
+
+	before := models[0].(Model).Spinner.View()
+	fanOut(models, SchedulerTickMsg{Time: time.Now(), ID: s.ID(), Sequence: 0})
+	after := models[0].(Model).Spinner.View()
+
+	assert.NotEqual(t, before, after)
+}
+
+func BenchmarkScheduler_Tick(b *testing.B) {
+	for _, n := range []int{10, 100, 1000, 5000} {
+		b.Run(fmt.Sprintf("models=%d", n), func(b *testing.B) {
+			s := NewScheduler(time.Millisecond)
+			_, models := scheduledModels(n, s)
+			for _, m := range models {
+				m.Init()
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fanOut(models, SchedulerTickMsg{Time: time.Now(), ID: s.ID(), Sequence: i})
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*n), "ns/model")
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package taskprogress
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SegmentKind names a part of the task line.
+type SegmentKind string
+
+const (
+	IconSegment    SegmentKind = "icon"
+	TitleSegment   SegmentKind = "title"
+	BarSegment     SegmentKind = "bar"
+	PercentSegment SegmentKind = "percent"
+	HintsSegment   SegmentKind = "hints"
+	ContextSegment SegmentKind = "context"
+	ElapsedSegment SegmentKind = "elapsed"
+	ETASegment     SegmentKind = "eta"
+	// SparklineSegment plots recent throughput (only shown when enabled with WithSparkline).
+	SparklineSegment SegmentKind = "sparkline"
+)
+
+// segmentPriorities orders segment kinds from the first to be dropped to the last when space is short.
+var segmentPriorities = map[SegmentKind]int{
+	ContextSegment:   contextPriority,
+	ETASegment:       etaPriority,
+	ElapsedSegment:   elapsedPriority,
+	HintsSegment:     hintsPriority,
+	SparklineSegment: sparklinePriority,
+	PercentSegment:   percentPriority,
+	BarSegment:       barPriority,
+	TitleSegment:     titlePriority,
+	IconSegment:      iconPriority,
+}
+
+// Segment is a single part of the task line, optionally with a style that overrides the model default for it.
+type Segment struct {
+	Kind  SegmentKind
+	Style *lipgloss.Style
+}
+
+// Layout is a validated, ordered list of segments that make up the task line. The zero value is the default layout.
+type Layout struct {
+	segments []Segment
+}
+
+var defaultSegments = []Segment{
+	{Kind: IconSegment},
+	{Kind: TitleSegment},
+	{Kind: BarSegment},
+	{Kind: SparklineSegment},
+	{Kind: HintsSegment},
+	{Kind: ContextSegment},
+}
+
+var templatePlaceholder = regexp.MustCompile(`\{\s*([a-zA-Z]+)\s*\}`)
+
+// NewLayout returns a layout made up of the given segments (in display order). An error is returned if a segment
+// kind is unknown or is used more than once.
+func NewLayout(segments ...Segment) (Layout, error) {
+	if len(segments) == 0 {
+		return Layout{}, fmt.Errorf("layout must have at least one segment")
+	}
+
+	seen := make(map[SegmentKind]struct{})
+	for _, s := range segments {
+		if _, ok := segmentPriorities[s.Kind]; !ok {
+			return Layout{}, fmt.Errorf("unknown segment %q", s.Kind)
+		}
+		if _, ok := seen[s.Kind]; ok {
+			return Layout{}, fmt.Errorf("segment %q is used more than once", s.Kind)
+		}
+		seen[s.Kind] = struct{}{}
+	}
+
+	return Layout{segments: append([]Segment(nil), segments...)}, nil
+}
+
+// ParseLayout returns a layout from a template of whitespace-separated segment placeholders,
+// e.g. "{icon} {bar} {title} {percent}".
+func ParseLayout(template string) (Layout, error) {
+	var (
+		segments []Segment
+		last     int
+	)
+	for _, match := range templatePlaceholder.FindAllStringSubmatchIndex(template, -1) {
+		if between := strings.TrimSpace(template[last:match[0]]); between != "" {
+			return Layout{}, fmt.Errorf("invalid layout template %q: expected only segment placeholders like {title}, got %q", template, between)
+		}
+		segments = append(segments, Segment{Kind: SegmentKind(strings.ToLower(template[match[2]:match[3]]))})
+		last = match[1]
+	}
+	if rest := strings.TrimSpace(template[last:]); rest != "" {
+		return Layout{}, fmt.Errorf("invalid layout template %q: expected only segment placeholders like {title}, got %q", template, rest)
+	}
+
+	l, err := NewLayout(segments...)
+	if err != nil {
+		return Layout{}, fmt.Errorf("invalid layout template %q: %w", template, err)
+	}
+	return l, nil
+}
+
+// MustParseLayout is like ParseLayout but panics if the template is invalid. This is intended for templates that
+// are known at compile time.
+func MustParseLayout(template string) Layout {
+	l, err := ParseLayout(template)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// WithStyle returns a copy of the layout where the given segment is rendered with the given style.
+func (l Layout) WithStyle(kind SegmentKind, style lipgloss.Style) Layout {
+	segments := l.Segments()
+	for i := range segments {
+		if segments[i].Kind == kind {
+			segments[i].Style = &style
+		}
+	}
+	return Layout{segments: segments}
+}
+
+// Segments returns the segments of the layout, in display order.
+func (l Layout) Segments() []Segment {
+	if len(l.segments) == 0 {
+		return append([]Segment(nil), defaultSegments...)
+	}
+	return append([]Segment(nil), l.segments...)
+}
+
+// Has indicates that the layout contains the given segment.
+func (l Layout) Has(kind SegmentKind) bool {
+	for _, s := range l.Segments() {
+		if s.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Segment) styleOr(fallback lipgloss.Style) lipgloss.Style {
+	if s.Style != nil {
+		return *s.Style
+	}
+	return fallback
+}
+
+// buildSegment returns the rendered segment for the given kind, or false if there is nothing to show for it.
+func (m Model) buildSegment(s Segment) (segment, bool) {
+	priority := segmentPriorities[s.Kind]
+
+	switch s.Kind {
+	case IconSegment:
+		icon := " " + m.icon(s.Style) + " "
+		w := lipgloss.Width(icon)
+		return segment{
+			render:   func(int) string { return icon },
+			priority: priority,
+			natural:  w,
+			compact:  w,
+			min:      w,
+			required: true,
+		}, true
+
+	case TitleSegment:
+		if m.title == "" {
+			return segment{}, false
+		}
+		titleStyle := m.TitleStyle
+		if m.state == StatePending {
+			titleStyle = m.TitlePendingStyle
+		}
+		title := textSegment(m.title, s.styleOr(titleStyle), m.TitleWidth, priority)
+		title.required = true
+		if m.TitleWidth > 0 && title.compact > m.TitleWidth {
+			// titles longer than the title column are truncated rather than pushing everything else out of alignment
+			title.natural, title.compact = m.TitleWidth, m.TitleWidth
+		}
+		return title, true
+
+	case BarSegment:
+		if !m.showProgress() {
+			return segment{}, false
+		}
+		style := s.styleOr(lipgloss.NewStyle())
+		return segment{
+			render: func(width int) string {
+				bar := m.ProgressBar
+				bar.Width = width
+				return style.Render(bar.View())
+			},
+			priority: priority,
+			natural:  m.ProgressBar.Width,
+			compact:  m.ProgressBar.Width,
+			min:      min(m.ProgressBar.Width, minBarWidth),
+			gap:      true,
+		}, true
+
+	case PercentSegment:
+		if !m.showProgress() {
+			return segment{}, false
+		}
+		return textSegment(fmt.Sprintf("%3.0f%%", m.progress.Percent()), s.styleOr(m.HintStyle), 0, priority), true
+
+	case SparklineSegment:
+		if m.completed || !m.sparkline.enabled() {
+			return segment{}, false
+		}
+		plot := s.styleOr(m.SparklineStyle).Render(m.sparkline.render(m.glyphs.Sparkline))
+		w := m.sparkline.window
+		return segment{
+			render:   func(int) string { return plot },
+			priority: priority,
+			natural:  w,
+			compact:  w,
+			min:      w,
+			gap:      true,
+		}, true
+
+	case HintsSegment:
+		var hints []string
+		if !m.completed || !m.HideStageOnSuccess {
+			for _, h := range m.hints {
+				hints = append(hints, fmt.Sprintf("%s%s%s", m.hintCap(false), h, m.hintCap(true)))
+			}
+		}
+		for _, hint := range []string{m.retryHint(), m.cancelHint()} {
+			if hint != "" {
+				hints = append(hints, fmt.Sprintf("%s%s%s", m.hintCap(false), hint, m.hintCap(true)))
+			}
+		}
+		if len(hints) == 0 {
+			return segment{}, false
+		}
+		return textSegment(strings.Join(hints, " "), s.styleOr(m.HintStyle), 0, priority), true
+
+	case ContextSegment:
+		if len(m.context) == 0 {
+			return segment{}, false
+		}
+		return fillSegment(strings.Join(m.context, " "), s.styleOr(m.ContextStyle), priority), true
+
+	case ElapsedSegment:
+		elapsed := m.elapsed()
+		if elapsed <= 0 {
+			return segment{}, false
+		}
+		return textSegment(formatDuration(elapsed), s.styleOr(m.HintStyle), 0, priority), true
+
+	case ETASegment:
+		remaining := m.remaining()
+		if remaining <= 0 {
+			return segment{}, false
+		}
+		return textSegment("eta "+formatDuration(remaining), s.styleOr(m.HintStyle), 0, priority), true
+	}
+
+	return segment{}, false
+}
+
+func (m Model) showProgress() bool {
+	if m.state == StateRetrying {
+		return false
+	}
+	return m.progress != nil && (!m.completed || (m.completed && !m.HideProgressOnSuccess && m.state != StateFailed && m.state != StateCanceled))
+}
+
+// elapsed is how long the task has been running (or ran for, once finished).
+func (m Model) elapsed() time.Duration {
+	if m.startedAt.IsZero() {
+		return 0
+	}
+	if !m.finishedAt.IsZero() {
+		return m.finishedAt.Sub(m.startedAt)
+	}
+	return time.Since(m.startedAt)
+}
+
+// remaining is the estimated time until the task completes, based on the progress ratio so far.
+func (m Model) remaining() time.Duration {
+	if m.completed || m.progress == nil {
+		return 0
+	}
+	ratio := m.progress.Ratio()
+	elapsed := m.elapsed()
+	if ratio <= 0 || elapsed <= 0 {
+		return 0
+	}
+	return time.Duration(float64(elapsed)/ratio) - elapsed
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(100 * time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
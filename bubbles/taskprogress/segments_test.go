@@ -0,0 +1,149 @@
+package taskprogress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/bubbly/bubbles/internal/testutil"
+)
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     []SegmentKind
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			name:     "all segments",
+			template: "{icon} {title} {bar} {sparkline} {percent} {hints} {context} {elapsed} {eta}",
+			want: []SegmentKind{
+				IconSegment, TitleSegment, BarSegment, SparklineSegment, PercentSegment, HintsSegment, ContextSegment, ElapsedSegment,
+				ETASegment,
+			},
+		},
+		{
+			name:     "whitespace and case are forgiving",
+			template: "  { Bar }\t{TITLE}\n",
+			want:     []SegmentKind{BarSegment, TitleSegment},
+		},
+		{
+			name:     "unknown segment",
+			template: "{icon} {title} {spinner}",
+			wantErr:  require.Error,
+		},
+		{
+			name:     "duplicate segment",
+			template: "{title} {bar} {title}",
+			wantErr:  require.Error,
+		},
+		{
+			name:     "literal text is not supported",
+			template: "{title} | {bar}",
+			wantErr:  require.Error,
+		},
+		{
+			name:     "empty template",
+			template: "",
+			wantErr:  require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			got, err := ParseLayout(tt.template)
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			var kinds []SegmentKind
+			for _, s := range got.Segments() {
+				kinds = append(kinds, s.Kind)
+			}
+			assert.Equal(t, tt.want, kinds)
+		})
+	}
+}
+
+func TestLayout_ZeroValueIsDefault(t *testing.T) {
+	assert.Equal(t, defaultSegments, Layout{}.Segments())
+	assert.True(t, Layout{}.Has(ContextSegment))
+	assert.False(t, Layout{}.Has(PercentSegment))
+}
+
+func TestLayout_WithStyle(t *testing.T) {
+	style := lipgloss.NewStyle().Italic(true)
+	l := MustParseLayout("{icon} {title}").WithStyle(TitleSegment, style)
+
+	segments := l.Segments()
+	assert.Nil(t, segments[0].Style)
+	require.NotNil(t, segments[1].Style)
+	assert.True(t, segments[1].Style.GetItalic())
+}
+
+func TestModel_View_Layout(t *testing.T) {
+	tests := []struct {
+		name    string
+		taskGen func(testing.TB) Model
+	}{
+		{
+			name: "bar before title without a spinner",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 40, 100
+				tsk.Layout = MustParseLayout("{bar} {title} {hints}")
+				return tsk
+			},
+		},
+		{
+			name: "counts on the right",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 40, 100
+				tsk.Layout = MustParseLayout("{icon} {title} {hints} {context} {percent}")
+				return tsk
+			},
+		},
+		{
+			name: "elapsed time once finished",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 100, 100
+				tsk.startedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				tsk.finishedAt = tsk.startedAt.Add(83 * time.Second)
+				tsk.Layout = MustParseLayout("{icon} {title} {elapsed} {context}")
+				return tsk
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsk := tt.taskGen(t)
+			got := testutil.RunModel(t, tsk, 1, TickMsg{
+				Time:     time.Now(),
+				Sequence: tsk.sequence,
+				ID:       tsk.id,
+			})
+			t.Log(got)
+			snaps.MatchSnapshot(t, got)
+		})
+	}
+}
+
+func TestModel_remaining(t *testing.T) {
+	prog, _, tsk := subject(t)
+	prog.N, prog.Total = 25, 100
+	tsk.startedAt = time.Now().Add(-10 * time.Second)
+
+	tsk = tick(tsk)
+
+	// a quarter of the work took ~10 seconds, so there should be ~30 seconds left
+	assert.InDelta(t, (30 * time.Second).Seconds(), tsk.remaining().Seconds(), 1)
+}
@@ -0,0 +1,75 @@
+package taskprogress
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// sparkline tracks the throughput of a task (units of progress per second) over the most recent refreshes.
+type sparkline struct {
+	window  int
+	samples []float64
+
+	lastCurrent int64
+	lastAt      time.Time
+}
+
+func newSparkline(window int) sparkline {
+	return sparkline{window: max(window, 0)}
+}
+
+func (s sparkline) enabled() bool {
+	return s.window > 0
+}
+
+// observe returns the sparkline with a new throughput sample derived from the progress made since the last
+// observation. The first observation only establishes a baseline.
+func (s sparkline) observe(current int64, at time.Time) sparkline {
+	if !s.enabled() {
+		return s
+	}
+
+	if !s.lastAt.IsZero() {
+		if elapsed := at.Sub(s.lastAt).Seconds(); elapsed > 0 {
+			rate := max(float64(current-s.lastCurrent)/elapsed, 0)
+
+			// always copy so that older copies of the model are never affected
+			samples := make([]float64, 0, s.window)
+			if over := len(s.samples) + 1 - s.window; over > 0 {
+				samples = append(samples, s.samples[over:]...)
+			} else {
+				samples = append(samples, s.samples...)
+			}
+			s.samples = append(samples, rate)
+		}
+	}
+
+	s.lastCurrent = current
+	s.lastAt = at
+	return s
+}
+
+// render plots the samples relative to the highest throughput in the window, right aligned. A stall is always
+// plotted with the lowest level, making it distinguishable from even the slowest progress.
+func (s sparkline) render(levels []string) string {
+	if len(levels) < 2 {
+		return strings.Repeat(" ", s.window)
+	}
+
+	var peak float64
+	for _, v := range s.samples {
+		peak = max(peak, v)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat(" ", s.window-len(s.samples)))
+	for _, v := range s.samples {
+		level := 0
+		if v > 0 && peak > 0 {
+			level = 1 + int(math.Round(v/peak*float64(len(levels)-2)))
+		}
+		sb.WriteString(levels[level])
+	}
+	return sb.String()
+}
@@ -0,0 +1,131 @@
+package taskprogress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/bubbly/bubbles/glyphs"
+	"github.com/anchore/bubbly/bubbles/internal/testutil"
+)
+
+func TestSparkline_observe(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newSparkline(3)
+
+	s = s.observe(0, start)
+	assert.Empty(t, s.samples, "the first observation only sets a baseline")
+
+	s = s.observe(100, start.Add(time.Second))
+	s = s.observe(300, start.Add(2*time.Second))
+	s = s.observe(300, start.Add(3*time.Second))
+	assert.Equal(t, []float64{100, 200, 0}, s.samples)
+
+	older := s
+	s = s.observe(350, start.Add(3500*time.Millisecond))
+	assert.Equal(t, []float64{200, 0, 100}, s.samples, "only the most recent window of samples is kept")
+	assert.Equal(t, []float64{100, 200, 0}, older.samples, "older copies are not affected")
+
+	s = s.observe(200, start.Add(4*time.Second))
+	assert.Equal(t, []float64{0, 100, 0}, s.samples, "progress going backwards is treated as a stall")
+}
+
+func TestSparkline_render(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  int
+		samples []float64
+		levels  []string
+		want    string
+	}{
+		{
+			name:   "no samples",
+			window: 4,
+			levels: glyphs.Unicode.Sparkline,
+			want:   "    ",
+		},
+		{
+			name:    "right aligned while filling",
+			window:  4,
+			samples: []float64{10, 20},
+			levels:  glyphs.Unicode.Sparkline,
+			want:    "  ▅█",
+		},
+		{
+			name:    "stalls use the lowest level",
+			window:  5,
+			samples: []float64{60, 0, 0, 1, 30},
+			levels:  glyphs.Unicode.Sparkline,
+			want:    "█▁▁▂▅",
+		},
+		{
+			name:    "ascii",
+			window:  3,
+			samples: []float64{0, 50, 100},
+			levels:  glyphs.ASCII.Sparkline,
+			want:    "_~#",
+		},
+		{
+			name:    "no levels",
+			window:  2,
+			samples: []float64{1, 2},
+			want:    "  ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := sparkline{window: tt.window, samples: tt.samples}
+			assert.Equal(t, tt.want, s.render(tt.levels))
+		})
+	}
+}
+
+func TestModel_View_Sparkline(t *testing.T) {
+	tests := []struct {
+		name    string
+		taskGen func(testing.TB) Model
+	}{
+		{
+			name: "disabled by default",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 40, 100
+				return tsk
+			},
+		},
+		{
+			name: "shows recent throughput",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 40, 100
+				WithSparkline(8)(&tsk)
+				tsk.sparkline.samples = []float64{5, 10, 20, 20, 0, 0, 15}
+				return tsk
+			},
+		},
+		{
+			name: "hidden once complete",
+			taskGen: func(tb testing.TB) Model {
+				prog, _, tsk := subject(tb)
+				prog.N, prog.Total = 100, 100
+				WithSparkline(8)(&tsk)
+				tsk.sparkline.samples = []float64{5, 10, 20}
+				return tsk
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsk := tt.taskGen(t)
+			got := testutil.RunModel(t, tsk, 1, TickMsg{
+				Time:     time.Now(),
+				Sequence: tsk.sequence,
+				ID:       tsk.id,
+			})
+			t.Log(got)
+			snaps.MatchSnapshot(t, got)
+		})
+	}
+}
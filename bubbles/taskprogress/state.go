@@ -0,0 +1,135 @@
+package taskprogress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wagoodman/go-progress"
+)
+
+// State is the lifecycle state of a task, as derived from its progress.
+type State int
+
+const (
+	StatePending State = iota
+	StateRunning
+	StateSuccess
+	StateWarning
+	StateFailed
+	StateCanceled
+	// StateRetrying is a task that is waiting to make another attempt at the work after a failed attempt.
+	StateRetrying
+)
+
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateRunning:
+		return "running"
+	case StateSuccess:
+		return "success"
+	case StateWarning:
+		return "warning"
+	case StateFailed:
+		return "failed"
+	case StateCanceled:
+		return "canceled"
+	case StateRetrying:
+		return "retrying"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// IsTerminal indicates that the task will not make any further progress.
+func (s State) IsTerminal() bool {
+	return s != StatePending && s != StateRunning && s != StateRetrying
+}
+
+// Starter can be implemented by progress producers (the value given to WithProgress or WithStager) to explicitly
+// signal that work has started, moving the task out of the pending state.
+type Starter interface {
+	Started() bool
+}
+
+// WarningError signals that a task completed, but with non-fatal issues. Since it wraps progress.ErrCompleted,
+// anything that only understands go-progress will consider the task complete.
+type WarningError struct {
+	Warnings []error
+}
+
+// CompletedWithWarnings returns an error that progress producers can report (in place of progress.ErrCompleted) to
+// indicate that the work finished but some non-fatal issues were encountered.
+func CompletedWithWarnings(warnings ...error) error {
+	return &WarningError{Warnings: warnings}
+}
+
+func (e *WarningError) Error() string {
+	if len(e.Warnings) == 0 {
+		return "completed with warnings"
+	}
+	var msgs []string
+	for _, w := range e.Warnings {
+		msgs = append(msgs, w.Error())
+	}
+	return fmt.Sprintf("completed with %d warning(s): %s", len(e.Warnings), strings.Join(msgs, "; "))
+}
+
+func (e *WarningError) Unwrap() []error {
+	return append([]error{progress.ErrCompleted}, e.Warnings...)
+}
+
+// IsWarning indicates that the given error signals completion with warnings.
+func IsWarning(err error) bool {
+	var w *WarningError
+	return errors.As(err, &w)
+}
+
+// StateOf determines the task state from a snapshot of progress.
+func StateOf(p progress.Progress) State {
+	if canceled, failed := causes(p.Error()); canceled {
+		// a canceled task will not make any further progress, regardless of whether it was able to finish. Any other
+		// failure takes precedence, since that is typically what caused the cancellation (e.g. with errgroup).
+		if failed {
+			return StateFailed
+		}
+		return StateCanceled
+	}
+	if !p.Complete() {
+		return StateRunning
+	}
+	err := p.Error()
+	switch {
+	case IsWarning(err):
+		return StateWarning
+	case err != nil && !errors.Is(err, progress.ErrCompleted):
+		return StateFailed
+	}
+	return StateSuccess
+}
+
+// causes reports whether the given error holds a cancellation, and whether it holds any failure other than a
+// cancellation (neither completion nor warnings are failures). Errors joined with errors.Join are inspected
+// individually.
+func causes(err error) (canceled, failed bool) {
+	switch e := err.(type) {
+	case nil, *WarningError:
+		return false, false
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			c, f := causes(child)
+			canceled, failed = canceled || c, failed || f
+		}
+		return canceled, failed
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			return causes(inner)
+		}
+	}
+	if errors.Is(err, context.Canceled) {
+		return true, false
+	}
+	return false, !errors.Is(err, progress.ErrCompleted)
+}
@@ -0,0 +1,120 @@
+package taskprogress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wagoodman/go-progress"
+)
+
+func TestTitle_Title(t *testing.T) {
+	titles := Title{
+		Default:  "default",
+		Running:  "running",
+		Success:  "success",
+		Warning:  "warning",
+		Failed:   "failed",
+		Canceled: "canceled",
+	}
+
+	tests := []struct {
+		name      string
+		prog      progress.Manual
+		titles    Title
+		wantState State
+		want      string
+	}{
+		{
+			name:      "running",
+			prog:      progress.Manual{N: 1, Total: 10},
+			titles:    titles,
+			wantState: StateRunning,
+			want:      "running",
+		},
+		{
+			name:      "success",
+			prog:      progress.Manual{N: 10, Total: 10},
+			titles:    titles,
+			wantState: StateSuccess,
+			want:      "success",
+		},
+		{
+			name:      "completed with warnings",
+			prog:      progress.Manual{N: 1, Total: 10, Err: CompletedWithWarnings(errors.New("skipped a file"))},
+			titles:    titles,
+			wantState: StateWarning,
+			want:      "warning",
+		},
+		{
+			name:      "warning falls back to the success title",
+			prog:      progress.Manual{N: 1, Total: 10, Err: CompletedWithWarnings()},
+			titles:    Title{Default: "default", Success: "success"},
+			wantState: StateWarning,
+			want:      "success",
+		},
+		{
+			name:      "failed",
+			prog:      progress.Manual{N: 10, Total: 10, Err: errors.New("woops")},
+			titles:    titles,
+			wantState: StateFailed,
+			want:      "failed",
+		},
+		{
+			name:      "canceled before completing",
+			prog:      progress.Manual{N: 3, Total: 10, Err: fmt.Errorf("downloading: %w", context.Canceled)},
+			titles:    titles,
+			wantState: StateCanceled,
+			want:      "canceled",
+		},
+		{
+			name:      "canceled because of another failure",
+			prog:      progress.Manual{N: 3, Total: 10, Err: errors.Join(errors.New("registry unauthorized"), context.Canceled)},
+			titles:    titles,
+			wantState: StateFailed,
+			want:      "failed",
+		},
+		{
+			name:      "canceled because of another wrapped failure",
+			prog:      progress.Manual{N: 3, Total: 10, Err: fmt.Errorf("scanning: %w", errors.Join(context.Canceled, errors.New("woops")))},
+			titles:    titles,
+			wantState: StateFailed,
+			want:      "failed",
+		},
+		{
+			name:      "canceled with several cancellations",
+			prog:      progress.Manual{N: 3, Total: 10, Err: errors.Join(context.Canceled, fmt.Errorf("downloading: %w", context.Canceled))},
+			titles:    titles,
+			wantState: StateCanceled,
+			want:      "canceled",
+		},
+		{
+			name:      "falls back to default",
+			prog:      progress.Manual{N: 10, Total: 10, Err: errors.New("woops")},
+			titles:    Title{Default: "default"},
+			wantState: StateFailed,
+			want:      "default",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.prog.Progress()
+			assert.Equal(t, tt.wantState, StateOf(p))
+			assert.Equal(t, tt.want, tt.titles.Title(p))
+		})
+	}
+}
+
+func TestWarningError(t *testing.T) {
+	skipped := errors.New("skipped a file")
+	err := CompletedWithWarnings(skipped)
+
+	assert.True(t, IsWarning(err))
+	assert.ErrorIs(t, err, progress.ErrCompleted)
+	assert.ErrorIs(t, err, skipped)
+	assert.True(t, progress.IsErrCompleted(err))
+	assert.False(t, IsWarning(progress.ErrCompleted))
+	assert.Equal(t, "completed with 1 warning(s): skipped a file", err.Error())
+}
@@ -1,34 +1,59 @@
 package taskprogress
 
 import (
-	"errors"
-
 	"github.com/wagoodman/go-progress"
 )
 
 type Title struct {
-	Default string
-	Running string
-	Success string
-	Failed  string
+	Default  string
+	Pending  string
+	Running  string
+	Success  string
+	Warning  string
+	Failed   string
+	Canceled string
+	Retrying string
 }
 
 func (t Title) Title(p progress.Progress) string {
-	isFailed := p.Complete() && (p.Error() != nil && !errors.Is(p.Error(), progress.ErrCompleted))
-	isSuccessful := p.Complete() && (p.Error() == nil || errors.Is(p.Error(), progress.ErrCompleted))
-	// isRunning := p.Current() > 0 && !p.Complete()
-	isRunning := !p.Complete()
+	return t.forState(StateOf(p))
+}
 
-	switch {
-	case isRunning:
+func (t Title) forState(s State) string {
+	switch s {
+	case StatePending:
+		if t.Pending != "" {
+			return t.Pending
+		}
+	case StateRunning:
 		if t.Running != "" {
 			return t.Running
 		}
-	case isFailed:
+	case StateFailed:
 		if t.Failed != "" {
 			return t.Failed
 		}
-	case isSuccessful:
+	case StateRetrying:
+		if t.Retrying != "" {
+			return t.Retrying
+		}
+		// retrying is still running the work
+		if t.Running != "" {
+			return t.Running
+		}
+	case StateCanceled:
+		if t.Canceled != "" {
+			return t.Canceled
+		}
+	case StateWarning:
+		if t.Warning != "" {
+			return t.Warning
+		}
+		// completing with warnings is still a completion
+		if t.Success != "" {
+			return t.Success
+		}
+	case StateSuccess:
 		if t.Success != "" {
 			return t.Success
 		}
@@ -9,6 +9,7 @@ import (
 	"github.com/scylladb/go-set/strset"
 
 	"github.com/anchore/bubbly"
+	"github.com/anchore/bubbly/bubbles/glyphs"
 )
 
 var _ tea.Model = (*Model)(nil)
@@ -33,6 +34,7 @@ type Model struct {
 }
 
 func NewModel() Model {
+	set := glyphs.Detect()
 	return Model{
 		nodes:    make(map[string]bubbly.VisibleModel),
 		children: make(map[string][]string),
@@ -42,16 +44,25 @@ func NewModel() Model {
 		// formatting options
 
 		Margin:                    "",
-		Indent:                    "   ",
-		Branch:                    "│  ",
-		Fork:                      "├──",
-		Leaf:                      "└──",
+		Indent:                    set.TreeIndent,
+		Branch:                    set.TreeBranch,
+		Fork:                      set.TreeFork,
+		Leaf:                      set.TreeLeaf,
 		Padding:                   "",
 		VerticalPadMultilineNodes: false,
 		RootsWithoutPrefix:        false,
 	}
 }
 
+// SetGlyphs selects the connectors used to draw the tree (by default these are detected from the terminal, see
+// glyphs.Detect).
+func (m *Model) SetGlyphs(set glyphs.Set) {
+	m.Indent = set.TreeIndent
+	m.Branch = set.TreeBranch
+	m.Fork = set.TreeFork
+	m.Leaf = set.TreeLeaf
+}
+
 func (m *Model) Add(parent string, id string, model bubbly.VisibleModel) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -8,11 +8,19 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/bubbly"
+	"github.com/anchore/bubbly/bubbles/glyphs"
 	"github.com/anchore/bubbly/bubbles/internal/testutil"
 )
 
 var _ bubbly.VisibleModel = (*dummyViewer)(nil)
 
+// newSubject returns a tree with fixed glyphs, so that snapshots do not depend on the terminal running the tests.
+func newSubject() Model {
+	m := NewModel()
+	m.SetGlyphs(glyphs.Unicode)
+	return m
+}
+
 type dummyViewer struct {
 	hidden bool
 	state  string
@@ -44,7 +52,7 @@ func TestModel_View(t *testing.T) {
 		{
 			name: "gocase",
 			taskGen: func(tb testing.TB) Model {
-				subject := NewModel()
+				subject := newSubject()
 
 				// └─ a
 				//    └─ a-a
@@ -58,7 +66,7 @@ func TestModel_View(t *testing.T) {
 		{
 			name: "sibling branches (one extra level)",
 			taskGen: func(tb testing.TB) Model {
-				subject := NewModel()
+				subject := newSubject()
 
 				// ├─ a
 				// │  ├─ a-a
@@ -78,7 +86,7 @@ func TestModel_View(t *testing.T) {
 		{
 			name: "sibling branches (lots of extra levels)",
 			taskGen: func(tb testing.TB) Model {
-				subject := NewModel()
+				subject := newSubject()
 
 				// ├─ a
 				// │  ├─ a-a
@@ -120,7 +128,7 @@ func TestModel_View(t *testing.T) {
 		{
 			name: "multiline node",
 			taskGen: func(tb testing.TB) Model {
-				subject := NewModel()
+				subject := newSubject()
 
 				// ├─ a
 				// │  more a...
@@ -144,7 +152,7 @@ func TestModel_View(t *testing.T) {
 		{
 			name: "padded multiline node",
 			taskGen: func(tb testing.TB) Model {
-				subject := NewModel()
+				subject := newSubject()
 				subject.VerticalPadMultilineNodes = true
 
 				// ├─ a
@@ -171,7 +179,7 @@ func TestModel_View(t *testing.T) {
 		{
 			name: "hidden nodes",
 			taskGen: func(tb testing.TB) Model {
-				subject := NewModel()
+				subject := newSubject()
 
 				// └─ a
 				//    └─ a-a
@@ -188,7 +196,7 @@ func TestModel_View(t *testing.T) {
 		{
 			name: "margin",
 			taskGen: func(tb testing.TB) Model {
-				subject := NewModel()
+				subject := newSubject()
 				subject.Margin = "   "
 
 				//    ├─ a
@@ -209,7 +217,7 @@ func TestModel_View(t *testing.T) {
 		{
 			name: "roots without prefix",
 			taskGen: func(tb testing.TB) Model {
-				subject := NewModel()
+				subject := newSubject()
 				subject.RootsWithoutPrefix = true
 
 				// a
@@ -230,7 +238,7 @@ func TestModel_View(t *testing.T) {
 		{
 			name: "horizontal padding",
 			taskGen: func(tb testing.TB) Model {
-				subject := NewModel()
+				subject := newSubject()
 				subject.Padding = "   "
 				subject.RootsWithoutPrefix = true
 